@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/reporter"
+	"os"
+	"time"
+)
+
+var (
+	asJSON      = flag.Bool("json", false, "print audit as json instead of human readable table")
+	format      = flag.String("format", "auto", "input format: json, ndjson, csv or auto to detect by file extension")
+	maxFailures = flag.Int("max-failures", 10, "maximum number of validation failures to print")
+	dist        = flag.String("dist", "", "expected event types distribution like 1:15,2:20,3:20,5:45, default generator distribution if not set")
+)
+
+// Audit is data quality report of a dump.
+type Audit struct {
+	TotalEvents        int                         `json:"total_events"`
+	ExpectedTypes      map[model.EventType]float64 `json:"expected_types"`
+	ActualTypes        map[model.EventType]float64 `json:"actual_types"`
+	MinDuration        int                         `json:"min_duration"`
+	MaxDuration        int                         `json:"max_duration"`
+	MeanDuration       float64                     `json:"mean_duration"`
+	EarliestDate       time.Time                   `json:"earliest_date"`
+	LatestDate         time.Time                   `json:"latest_date"`
+	DistinctCalling    int                         `json:"distinct_calling_numbers"`
+	DistinctCalled     int                         `json:"distinct_called_numbers"`
+	ValidationFailures int                         `json:"validation_failures"`
+	Failures           []string                    `json:"failures,omitempty"`
+}
+
+// Audit will print data quality report of provided dump: type distribution, durations, dates,
+// distinct numbers and validation failures.
+//
+// arg 1 - file to audit
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <input file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// validate inputs firstly
+	if flag.NArg() != 1 {
+		panic(fmt.Errorf("invalid number of arguments, 1 expected, got %d", flag.NArg()))
+	}
+
+	inputFile := flag.Arg(0)
+	inputFormat := *format
+	if inputFormat == "auto" {
+		inputFormat = generator.FormatFromExt(inputFile)
+	}
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open input file : %+v", err))
+	}
+	defer file.Close()
+
+	events, err := generator.ReadEvents(bufio.NewReader(file), inputFormat)
+	if err != nil {
+		panic(fmt.Errorf("unable to read events : %+v", err))
+	}
+
+	expected := generator.DefaultDistribution()
+	if *dist != "" {
+		expected, err = generator.ParseDistribution(*dist)
+		if err != nil {
+			panic(fmt.Errorf("invalid event types distribution : %+v", err))
+		}
+	}
+
+	a := audit(events, expected, *maxFailures)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(a); err != nil {
+			panic(fmt.Errorf("unable to marshall audit : %+v", err))
+		}
+		return
+	}
+	printAudit(a)
+}
+
+// audit will collect data quality report of events, at most maxFailures validation messages are kept.
+func audit(events model.Events, expected *generator.Distribution, maxFailures int) Audit {
+	a := Audit{
+		TotalEvents:   len(events),
+		ExpectedTypes: expected.Percentages(),
+		ActualTypes:   reporter.EventTypeBreakdown(events),
+	}
+	a.EarliestDate, a.LatestDate = events.EarliestLatest()
+
+	calling := map[int]struct{}{}
+	called := map[int]struct{}{}
+	total := 0
+	for i, e := range events {
+		if i == 0 || e.DurationSeconds < a.MinDuration {
+			a.MinDuration = e.DurationSeconds
+		}
+		if i == 0 || e.DurationSeconds > a.MaxDuration {
+			a.MaxDuration = e.DurationSeconds
+		}
+		total += e.DurationSeconds
+
+		calling[e.CallingNumber] = struct{}{}
+		called[e.CalledNumber] = struct{}{}
+
+		if err := e.Validate(); err != nil {
+			a.ValidationFailures++
+			if len(a.Failures) < maxFailures {
+				a.Failures = append(a.Failures, err.Error())
+			}
+		}
+	}
+
+	if len(events) > 0 {
+		a.MeanDuration = float64(total) / float64(len(events))
+	}
+	a.DistinctCalling = len(calling)
+	a.DistinctCalled = len(called)
+	return a
+}
+
+// printAudit will print audit as human readable table.
+func printAudit(a Audit) {
+	fmt.Printf("Total Events             : %s\n", reporter.FormatNumber(a.TotalEvents))
+	fmt.Printf("Duration min/mean/max    : %d / %.2f / %d\n", a.MinDuration, a.MeanDuration, a.MaxDuration)
+	fmt.Printf("Date Range               : %s - %s\n", a.EarliestDate.Format(time.RFC3339), a.LatestDate.Format(time.RFC3339))
+	fmt.Printf("Distinct Calling Numbers : %s\n", reporter.FormatNumber(a.DistinctCalling))
+	fmt.Printf("Distinct Called Numbers  : %s\n", reporter.FormatNumber(a.DistinctCalled))
+	fmt.Printf("Validation Failures      : %s\n", reporter.FormatNumber(a.ValidationFailures))
+	for _, f := range a.Failures {
+		fmt.Printf("  %s\n", f)
+	}
+	fmt.Println()
+	reporter.PrintEventTypeBreakdown(os.Stdout, a.ExpectedTypes, a.ActualTypes)
+}
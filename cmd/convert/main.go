@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"os"
+	"time"
+)
+
+var (
+	from = flag.String("from", "", "format of the input file: json, ndjson or csv, detected by extension if empty")
+	to   = flag.String("to", "", "format of the output file: json, ndjson, csv or cdr, detected by extension if empty")
+)
+
+// Convert will write events of existing dump in another format, so dataset doesn't have to be regenerated.
+// File is streamed, so it doesn't have to fit in memory.
+//
+// arg 1 is path to file to convert
+// arg 2 is path to converted file
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <input file> <output file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// log time duration on application shutdown
+	start := time.Now()
+	defer func() {
+		fmt.Println("================")
+		fmt.Printf("Execution Time : %v\n", time.Since(start))
+	}()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+
+	inputFile := flag.Arg(0)
+	outPutFile := flag.Arg(1)
+	if *from == "" {
+		*from = generator.FormatFromExt(inputFile)
+	}
+	if *to == "" {
+		*to = generator.FormatFromExt(outPutFile)
+	}
+
+	converted, err := convert(inputFile, *from, outPutFile, *to)
+	if err != nil {
+		panic(fmt.Errorf("unable to convert events : %+v", err))
+	}
+
+	fmt.Printf("%d events converted from %s to %s, written to %s\n", converted, *from, *to, outPutFile)
+}
+
+// convert will stream events of input file in provided format to output file in another format.
+// Returns number of converted events.
+func convert(inputFile, inputFormat, outPutFile, outputFormat string) (int, error) {
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return 0, fmt.Errorf("unable to open input file : %w", err)
+	}
+	defer file.Close()
+
+	sink, err := generator.NewFileSink(outPutFile, outputFormat, false, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create output file : %w", err)
+	}
+
+	converted := 0
+	skipped, err := generator.StreamEvents(bufio.NewReader(file), inputFormat, func(e *model.Event) error {
+		converted++
+		return sink.Write(e)
+	})
+	if err != nil {
+		sink.Close()
+		return converted, err
+	}
+	if skipped > 0 {
+		fmt.Printf("skipped %d malformed events\n", skipped)
+	}
+	if err := sink.Close(); err != nil {
+		return converted, fmt.Errorf("unable to write events : %w", err)
+	}
+	return converted, nil
+}
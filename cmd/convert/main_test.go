@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// testEvents returns events with all the fields set, empty attributes included.
+func testEvents() model.Events {
+	date := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+	return model.Events{
+		generator.NewEvent(generator.WithRef("ref-1"), generator.WithDate(date),
+			generator.WithAttributes([8]string{"a1", "", "with, comma", `with "quotes"`})),
+		generator.NewEvent(generator.WithRef("ref-2"), generator.WithDate(date.Add(time.Hour)),
+			generator.WithType(model.EventTypeSMS), generator.WithDuration(0)),
+	}
+}
+
+// writeFile writes events to the file in provided format.
+func writeFile(t *testing.T, filename, format string, events model.Events) {
+	t.Helper()
+	sink, err := generator.NewFileSink(filename, format, false, 0644)
+	if err != nil {
+		t.Fatalf("unable to create sink : %v", err)
+	}
+	for _, e := range events {
+		if err := sink.Write(e); err != nil {
+			t.Fatalf("unable to write event : %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unable to close sink : %v", err)
+	}
+}
+
+// readFile reads events of the file in provided format.
+func readFile(t *testing.T, filename, format string) model.Events {
+	t.Helper()
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("unable to open %s : %v", filename, err)
+	}
+	defer file.Close()
+
+	var events model.Events
+	if _, err := generator.StreamEvents(file, format, func(e *model.Event) error {
+		events = append(events, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("unable to read %s : %v", filename, err)
+	}
+	return events
+}
+
+// assertEvents fails the test if events differ, dates are compared as instants.
+func assertEvents(t *testing.T, expected, actual model.Events) {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		x, y := *expected[i], *actual[i]
+		if !x.EventDate.Equal(y.EventDate) {
+			t.Errorf("event %d : expected date %v, got %v", i, x.EventDate, y.EventDate)
+		}
+		x.EventDate, y.EventDate = time.Time{}, time.Time{}
+		if x != y {
+			t.Errorf("event %d : expected %+v, got %+v", i, x, y)
+		}
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	tests := []struct {
+		from, to string
+	}{
+		{from: generator.FormatJSON, to: generator.FormatCSV},
+		{from: generator.FormatCSV, to: generator.FormatJSON},
+		{from: generator.FormatNDJSON, to: generator.FormatCSV},
+		{from: generator.FormatCSV, to: generator.FormatNDJSON},
+	}
+
+	for _, test := range tests {
+		t.Run(test.from+" to "+test.to, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, "events."+test.from)
+			converted := filepath.Join(dir, "converted."+test.to)
+			back := filepath.Join(dir, "back."+test.from)
+			events := testEvents()
+			writeFile(t, input, test.from, events)
+
+			if n, err := convert(input, test.from, converted, test.to); err != nil {
+				t.Fatalf("unable to convert events : %v", err)
+			} else if n != len(events) {
+				t.Errorf("expected %d converted events, got %d", len(events), n)
+			}
+			if n, err := convert(converted, test.to, back, test.from); err != nil {
+				t.Fatalf("unable to convert events back : %v", err)
+			} else if n != len(events) {
+				t.Errorf("expected %d converted back events, got %d", len(events), n)
+			}
+
+			assertEvents(t, events, readFile(t, converted, test.to))
+			assertEvents(t, events, readFile(t, back, test.from))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"os"
+)
+
+var (
+	format = flag.String("format", "", "format of the input file: json, ndjson or csv, detected by extension if empty")
+)
+
+// Count will print number of events stored in the file and nothing else, so it could be used in scripts.
+// File is streamed and events are not decoded, so it's fast and memory usage doesn't depend on the file size.
+// Exit code is 1 if file can't be read and 3 if it's malformed.
+//
+// arg 1 is path to file with events, - for stdin
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <input file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "invalid number of arguments, 1 expected, got %d\n", flag.NArg())
+		os.Exit(2)
+	}
+
+	inputFile := flag.Arg(0)
+	if *format == "" {
+		*format = generator.FormatFromExt(inputFile)
+	}
+
+	file := os.Stdin
+	if inputFile != "-" {
+		var err error
+		file, err = os.Open(inputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to open input file : %+v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+	}
+
+	count, err := generator.CountEvents(file, *format)
+	if errors.Is(err, generator.ErrBadInput) {
+		fmt.Fprintf(os.Stderr, "malformed input file : %+v\n", err)
+		os.Exit(3)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to count events : %+v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(count)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"os"
+	"time"
+)
+
+// Dedup will remove events with the same content as one of the previous events, event refs are ignored,
+// so duplicates produced by different runs are removed too. The first event of duplicates is kept.
+// File is streamed, only hashes of kept events are kept in memory, ~150 bytes per event.
+// Json array output is buffered until all the events are read, so use ndjson or csv output for large files.
+//
+// arg 1 is path to file to deduplicate, format is detected by extension
+// arg 2 is path to deduplicated file, format is detected by extension
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s <input file> <output file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// log time duration on application shutdown
+	start := time.Now()
+	defer func() {
+		fmt.Println("================")
+		fmt.Printf("Execution Time : %v\n", time.Since(start))
+	}()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+
+	inputFile := flag.Arg(0)
+	outPutFile := flag.Arg(1)
+
+	// output is truncated before input is read
+	if sameFile(inputFile, outPutFile) {
+		panic(fmt.Errorf("output file must differ from input file '%s'", inputFile))
+	}
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open input file : %+v", err))
+	}
+	defer file.Close()
+
+	sink, err := generator.NewFileSink(outPutFile, generator.FormatFromExt(outPutFile), false, 0644)
+	if err != nil {
+		panic(fmt.Errorf("unable to create output file : %+v", err))
+	}
+
+	seen := map[string]struct{}{}
+	read := 0
+	skipped, err := generator.StreamEvents(bufio.NewReader(file), generator.FormatFromExt(inputFile), func(e *model.Event) error {
+		read++
+		hash := e.Hash()
+		if _, ok := seen[hash]; ok {
+			return nil
+		}
+		seen[hash] = struct{}{}
+		return sink.Write(e)
+	})
+	if err != nil {
+		panic(fmt.Errorf("unable to deduplicate events : %+v", err))
+	}
+	if err := sink.Close(); err != nil {
+		panic(fmt.Errorf("unable to write events : %+v", err))
+	}
+
+	if skipped > 0 {
+		fmt.Printf("skipped %d malformed events\n", skipped)
+	}
+	fmt.Printf("%d events kept, %d duplicates removed, written to %s\n", len(seen), read-len(seen), outPutFile)
+}
+
+// sameFile reports whether both paths point to the same existing file, links are followed.
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(infoA, infoB)
+}
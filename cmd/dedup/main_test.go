@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSameFile(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "events.ndjson")
+	if err := os.WriteFile(input, []byte("\n"), 0644); err != nil {
+		t.Fatalf("unable to write file : %v", err)
+	}
+	link := filepath.Join(dir, "link.ndjson")
+	if err := os.Symlink(input, link); err != nil {
+		t.Fatalf("unable to create link : %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		output   string
+		expected bool
+	}{
+		{name: "same path", output: input, expected: true},
+		{name: "relative path", output: filepath.Join(dir, ".", "events.ndjson"), expected: true},
+		{name: "link", output: link, expected: true},
+		{name: "missing output", output: filepath.Join(dir, "dedup.ndjson")},
+	}
+	for _, test := range tests {
+		if actual := sameFile(input, test.output); actual != test.expected {
+			t.Errorf("%s : expected %t, got %t", test.name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"github.com/xo/dburl"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// exportQuery selects all the events, order by event ref makes exports of the same table comparable.
+const exportQuery = `select event_source, event_ref, event_type, event_date, calling_number, called_number, location,
+       duration_seconds, attr_1, attr_2, attr_3, attr_4, attr_5, attr_6, attr_7, attr_8
+from event
+order by event_ref`
+
+var (
+	tz = flag.String("tz", "UTC", "time zone event dates are stored in, e.g. Europe/Kyiv")
+)
+
+// Export will write events stored in provided DB to a file, so loaded table could be diffed with generated dump.
+// Rows are streamed, so the table doesn't have to fit in memory.
+//
+// arg 1 is DB URL for database to export
+// arg 2 is path to output file, format is detected by extension: ndjson or csv
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <database url> <output file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// log time duration on application shutdown
+	start := time.Now()
+	defer func() {
+		fmt.Println("================")
+		fmt.Printf("Execution Time : %v\n", time.Since(start))
+	}()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+
+	outPutFile := flag.Arg(1)
+	format := generator.FormatFromExt(outPutFile)
+	if format != generator.FormatNDJSON && format != generator.FormatCSV {
+		panic(fmt.Errorf("format '%s' can't be streamed, .ndjson or .csv output file expected", format))
+	}
+
+	location, err := time.LoadLocation(*tz)
+	if err != nil {
+		panic(fmt.Errorf("unable to load time zone '%s' : %+v", *tz, err))
+	}
+
+	dbUrl := flag.Arg(0)
+	url, err := dburl.Parse(dbUrl)
+	if err != nil {
+		panic(fmt.Errorf("unable to parse database URL '%s' : %+v", dbUrl, err))
+	}
+
+	// stop export gracefully on interruption
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, _, err := dialect.Open(ctx, url)
+	if err != nil {
+		panic(fmt.Errorf("unable to connect to database : %+v", err))
+	}
+	defer db.Close()
+
+	sink, err := generator.NewFileSink(outPutFile, format, false, 0644)
+	if err != nil {
+		panic(fmt.Errorf("unable to create output file : %+v", err))
+	}
+
+	exported, err := export(ctx, db, location, sink)
+	if closeErr := sink.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		panic(fmt.Errorf("unable to export events : %+v", err))
+	}
+
+	fmt.Printf("%d events exported to %s\n", exported, outPutFile)
+}
+
+// export will write every stored event to sink, returns number of exported events.
+func export(ctx context.Context, db *sql.DB, location *time.Location, sink generator.Sink) (int, error) {
+	rows, err := db.QueryContext(ctx, exportQuery)
+	if err != nil {
+		return 0, fmt.Errorf("unable to query events : %w", err)
+	}
+	defer rows.Close()
+
+	exported := 0
+	for rows.Next() {
+		e, err := scanEvent(rows, location)
+		if err != nil {
+			return exported, err
+		}
+		if err := sink.Write(e); err != nil {
+			return exported, fmt.Errorf("unable to write event : %w", err)
+		}
+		exported++
+	}
+	if err := rows.Err(); err != nil {
+		return exported, fmt.Errorf("unable to read events : %w", err)
+	}
+	return exported, nil
+}
+
+// scanEvent reconstructs event of the current row, NULL attributes are exported as empty.
+func scanEvent(rows *sql.Rows, location *time.Location) (*model.Event, error) {
+	var e model.Event
+	var date interface{}
+	var attrs [8]sql.NullString
+
+	err := rows.Scan(&e.EventSource, &e.EventRef, &e.EventType, &date, &e.CallingNumber, &e.CalledNumber, &e.Location,
+		&e.DurationSeconds, &attrs[0], &attrs[1], &attrs[2], &attrs[3], &attrs[4], &attrs[5], &attrs[6], &attrs[7])
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan event : %w", err)
+	}
+
+	e.EventDate, err = dialect.ParseTimestamp(date, location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date of event %s : %w", e.EventRef, err)
+	}
+
+	var values [8]string
+	for i, attr := range attrs {
+		values[i] = attr.String
+	}
+	e.SetAttributes(values)
+	return &e, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// insertEvent stores event the way loader does with -keep-time, empty attributes are stored as NULL.
+func insertEvent(t *testing.T, db *sql.DB, e *model.Event) {
+	t.Helper()
+	args := []interface{}{e.EventSource, e.EventRef, e.EventType, e.EventDate.Format("2006-01-02 15:04:05.999999"),
+		e.CallingNumber, e.CalledNumber, e.Location, e.DurationSeconds}
+	for _, attr := range e.Attributes() {
+		if attr == "" {
+			args = append(args, nil)
+		} else {
+			args = append(args, attr)
+		}
+	}
+
+	_, err := db.Exec(`insert into event(event_source, event_ref, event_type, event_date, calling_number, called_number,
+		location, duration_seconds, attr_1, attr_2, attr_3, attr_4, attr_5, attr_6, attr_7, attr_8)
+		values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, args...)
+	if err != nil {
+		t.Fatalf("unable to insert event : %v", err)
+	}
+}
+
+func TestExportSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("unable to open database : %v", err)
+	}
+	defer db.Close()
+	// every connection of in-memory database is a separate database
+	db.SetMaxOpenConns(1)
+
+	for _, stmt := range (dialect.SQLite{}).Schema() {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("unable to create schema : %v", err)
+		}
+	}
+
+	date := time.Date(2022, 3, 14, 15, 9, 26, 535000000, time.UTC)
+	second := generator.NewEvent(generator.WithRef("ref-2"), generator.WithDate(date))
+	// NULL attributes are exported as empty ones
+	first := generator.NewEvent(generator.WithRef("ref-1"), generator.WithDate(date.Add(time.Hour)))
+	first.SetAttributes([8]string{"a1"})
+	for _, e := range []*model.Event{second, first} {
+		e.DurationMillis = 0
+		insertEvent(t, db, e)
+	}
+
+	sink := &generator.MemorySink{}
+	exported, err := export(context.Background(), db, time.UTC, sink)
+	if err != nil {
+		t.Fatalf("unable to export events : %v", err)
+	}
+	if exported != 2 {
+		t.Errorf("expected 2 exported events, got %d", exported)
+	}
+
+	// events are ordered by ref
+	expected := model.Events{first, second}
+	if len(sink.Events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(sink.Events))
+	}
+	for i, e := range expected {
+		actual := *sink.Events[i]
+		if !actual.EventDate.Equal(e.EventDate) {
+			t.Errorf("event %d : expected date %v, got %v", i, e.EventDate, actual.EventDate)
+		}
+		actual.EventDate = e.EventDate
+		if actual != *e {
+			t.Errorf("event %d : expected %+v, got %+v", i, *e, actual)
+		}
+	}
+}
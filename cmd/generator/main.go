@@ -1,18 +1,130 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/metrics"
 	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
-	"github.com/google/uuid"
-	"io/ioutil"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/reporter"
+	"io"
+	"math"
 	"math/rand"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
+	"unicode"
 )
 
+var (
+	seed             = flag.Int64("seed", 0, "seed for random generator, new seed is used for every run if not set")
+	bench            = flag.Int("bench", 0, "run generation provided number of times and print aggregated statistics")
+	statsFile        = flag.String("stats", "execution_statistics.json", "file to store execution statistics in")
+	breakdown        = flag.Bool("breakdown", false, "print expected and actual event types distribution after generation")
+	format           = flag.String("format", formatJSON, "output format: json (single array), ndjson (event per line), csv or cdr (call detail records with trailer)")
+	appendOut        = flag.Bool("append", false, "append events to the output file instead of overwriting it, ndjson and csv formats only")
+	checkUniq        = flag.Bool("check-unique", false, "fail on event ref collision, requires ~40 bytes of memory per event")
+	peakHours        = flag.String("peak-hours", "", "hours window like 9-21 when events are more likely to happen, hours are uniform if not set")
+	peakFactor       = flag.Float64("peak-factor", 3, "how many times peak hours are more likely than the rest of the day")
+	refVersion       = flag.Int("ref-version", 4, "UUID version of event refs: 4 (random) or 7 (time ordered)")
+	metricsAddr      = flag.String("metrics-addr", "", "address to expose Prometheus metrics on, e.g. :9090, disabled if not set")
+	tz               = flag.String("tz", "UTC", "time zone of generated event dates, e.g. Europe/Kyiv, peak hours are local to it")
+	perm             = flag.String("perm", "0644", "permissions of created output file in octal")
+	rate             = flag.Float64("rate", 0, "number of events per second to emit in ndjson and csv formats to simulate a live feed, 0 - as fast as possible")
+	sinkType         = flag.String("sink", sinkFile, "comma separated destinations of generated events: file, stdout or kafka, e.g. file,kafka, output file argument is needed for file only")
+	kafkaBrokers     = flag.String("kafka-brokers", "localhost:9092", "comma separated kafka brokers of kafka sink")
+	kafkaTopic       = flag.String("kafka-topic", "events", "topic of kafka sink")
+	correlate        = flag.Bool("correlate-location", false, "derive location from area code of calling number")
+	dist             = flag.String("dist", "", "event types distribution like 1:15,2:20,3:20,4:5,5:40, any of valid event types could be used")
+	fields           = flag.String("fields", "", "comma separated json names of event fields to write, e.g. event_ref,event_type,event_date, all fields if not set")
+	attrCardinality  = flag.String("attr-cardinality", "", "number of distinct values of attributes like 6:5,7:50, listed attributes are drawn from a fixed pool, the rest stay random")
+	fastUUID         = flag.Bool("fast-uuid", false, "generate event refs from buffered random source to amortize crypto random reads")
+	maxFileSize      = flag.String("max-file-size", "", "roll output to numbered files like events.0001.ndjson once file reaches the size, e.g. 500MB, ndjson and csv formats only")
+	durations        = flag.String("durations", "", "duration profiles by event type like 1:uniform:60,5:exp:600, uniform takes max and exp mean seconds, other types are uniform up to 100 seconds")
+	nullProb         = flag.Float64("null-prob", 0, "probability from 0.0 to 1.0 of every attribute to be empty, so missing attributes are simulated")
+	since            = flag.String("since", "", "earliest event date, RFC3339 or YYYY-MM-DD in -tz zone, 2010-01-01 if only -until is set")
+	until            = flag.String("until", "", "event dates are before it, RFC3339 or YYYY-MM-DD in -tz zone, 2021-01-01 if only -since is set")
+	jsonCase         = flag.String("json-case", "snake", "case of json keys: snake (event_ref) or camel (eventRef)")
+	locationLen      = flag.Int("location-len", 0, "length of generated locations, random from 1 to 40 if not set")
+	locationAlphabet = flag.String("location-alphabet", "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890", "ASCII characters of generated locations")
+	trafficShape     = flag.String("traffic-shape", generator.ShapeUniform, "how event dates are spread over the dates window: uniform, bursty (random spikes) or diurnal (day/night sine wave)")
+	sourceMax        = flag.Int64("source-max", generator.DefaultNumberMax, "exclusive upper bound of generated event sources")
+	numberMax        = flag.Int64("number-max", generator.DefaultNumberMax, "exclusive upper bound of generated calling and called numbers")
+	manifest         = flag.Bool("manifest", false, "write <output file>.sha256 manifest with checksum and number of events after generation")
+	sessionFraction  = flag.Float64("sessions", 0, "probability from 0.0 to 1.0 of event to start a multi-part session, parts share numbers and carry session id in attr_1 and part number in attr_2")
+	sessionParts     = flag.Int("session-parts", 5, "max number of parts of a session, at least 2")
+	bufferSizeFlag   = flag.String("buffer-size", "1MB", "size of output write buffer, e.g. 64KB")
+	fraudPrefixes    = flag.String("fraud-prefixes", "", "comma separated called number prefixes of premium fraud scenarios, e.g. 900,976")
+	fraudRate        = flag.Float64("fraud-rate", 0.01, "probability from 0.0 to 1.0 of event to call number with one of fraud prefixes")
+	configFile       = flag.String("config", "", "YAML or JSON file with generation parameters named after flags, count and output set arguments, explicit flags override file values")
+	corruptRate      = flag.Float64("corrupt-rate", 0, "probability from 0.0 to 1.0 of event to be intentionally invalid, so consumers error handling could be tested")
+	corruptKind      = flag.String("corrupt-kind", generator.CorruptAny, "how corrupted events are invalid: duration (negative), ref (not UUID), type (unknown), date (missing), location (missing) or any")
+	perMonth         = flag.String("per-month", "", "number of events per month like 2020-01:100000,2020-02:50000 in -tz zone, number of events argument is omitted")
+	noColor          = flag.Bool("no-color", false, "disable colors of the report, they are disabled automatically if output is not a terminal or NO_COLOR is set")
+	durationUnit     = flag.String("duration-unit", "s", "unit of generated durations: s or ms, ms adds duration_millis field to json formats, duration_seconds is derived from it")
+	validateDist     = flag.Bool("validate-distribution", false, "fail if percentage of any event type differs from distribution by more than -distribution-tolerance")
+	distTolerance    = flag.Float64("distribution-tolerance", 1, "allowed deviation of event type percentage from distribution in percentage points")
+	lateRate         = flag.Float64("late-rate", 0, "fraction from 0.0 to 1.0 of events arriving late, their dates are shifted back by up to -late-delay, combine with -sort to get out of order stream")
+	lateDelay        = flag.Duration("late-delay", time.Hour, "max delay of late events")
+	sortEvents       = flag.Bool("sort", false, "write events in date order, events are kept in memory until all are generated, late events are delayed after sorting")
+	thousandsSep     = flag.String("thousands-sep", "", "thousands separator of reported numbers, e.g. ' ' or ., picked by LC_NUMERIC locale if not set, comma by default")
+	deriveRules      = flag.String("derive", "", "derive attributes from other fields deterministically, e.g. attr3=hash(calling_number),attr4=value(location), functions: hash, value")
+	pretty           = flag.Bool("pretty", false, "indent json array for human inspection, json format only and at most 10,000 events, as file gets several times larger")
+	appendArray      = flag.Bool("append-array", false, "continue json array stored in the output file, the file is rewritten only after all the events are generated")
+)
+
+// gitCommit is commit the generator is built from, it's set by build flags:
+//
+//	go build -ldflags "-X main.gitCommit=$(git rev-parse --short HEAD)"
+var gitCommit string
+
+// supported output formats.
+const (
+	formatJSON   = generator.FormatJSON
+	formatNDJSON = generator.FormatNDJSON
+	formatCSV    = generator.FormatCSV
+	formatCDR    = generator.FormatCDR
+)
+
+// distribution of event types to generate.
+var distribution = generator.DefaultDistribution()
+
+// eventOptions are options of every generated event configured by flags.
+var eventOptions []generator.EventOption
+
+// months are numbers of events per month, events are generated within their month if set.
+var months []generator.MonthCount
+
+// maxPrettyEvents is max number of events of indented output, larger outputs are not for human inspection.
+const maxPrettyEvents = 10000
+
+// late delays dates of a fraction of events, nil if late arrivals are disabled.
+var late *generator.LateArrivals
+
+// projection selects serialized fields, all the fields are serialized if it's nil.
+var projection *model.Projection
+
+// jobMetrics are generation metrics, nil if metrics are disabled.
+var jobMetrics *metrics.Metrics
+
+// fileMode is permissions of created output file.
+var fileMode os.FileMode = 0644
+
+// maxSize is size limit of a single output file, output is not split if it's 0.
+var maxSize int64
+
+// out is destination of progress and timing messages, it's switched to standard error
+// when events are written to standard output, so data stream stays clean.
+var out io.Writer = os.Stdout
+
+// metricsBatchSize is number of events reported to metrics at once.
+const metricsBatchSize = 10000
+
 // run generation of costed events and save them to provided file.
 // costed event will have following types and probability:
 // * type 1 - 15%
@@ -22,90 +134,673 @@ import (
 //
 // rest of fields will be filled randomly.
 //
-// arg 1 - number of events to generate
-// arg 2 - output file.
+// arg 1 - number of events to generate or range like 1000-5000 to generate random number of events within, omitted if -per-month is set
+// arg 2 - output file, required for file sink only, - writes events to standard output.
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <number of events | min-max> [output file]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// flags set explicitly take precedence over config values
+	args := flag.Args()
+	if *configFile != "" {
+		config, err := generator.LoadConfig(*configFile)
+		if err != nil {
+			panic(fmt.Errorf("invalid config : %+v", err))
+		}
+		for name, value := range config.Values() {
+			if isFlagSet(name) {
+				continue
+			}
+			if err := flag.Set(name, value); err != nil {
+				panic(fmt.Errorf("invalid config value %s '%s' : %+v", name, value, err))
+			}
+		}
+		if len(args) == 0 && config.Count != "" {
+			args = append(args, config.Count)
+		}
+		if len(args) == 1 && config.Output != "" {
+			args = append(args, config.Output)
+		}
+	}
+
+	if *noColor {
+		reporter.Colors = false
+	}
+	if *thousandsSep != "" {
+		reporter.Separator = *thousandsSep
+	}
+
+	var err error
+	// number of events is derived from months, so the only argument is output file
+	if *perMonth != "" {
+		// months are parsed again once time zone is loaded
+		counts, err := generator.ParseMonthCounts(*perMonth)
+		if err != nil {
+			panic(fmt.Errorf("invalid per month counts : %+v", err))
+		}
+		args = append([]string{strconv.Itoa(generator.TotalCount(counts))}, args...)
+	}
+
+	sinks, err = parseSinks(*sinkType)
+	if err != nil {
+		panic(fmt.Errorf("invalid sink : %+v", err))
+	}
+
+	// output file - means events are piped to standard output instead of file, ndjson is used unless format is set explicitly
+	if len(args) == 2 && args[1] == "-" {
+		for i := range sinks {
+			if sinks[i] == sinkFile {
+				sinks[i] = sinkStdout
+			}
+		}
+		if !isFlagSet("format") {
+			*format = formatNDJSON
+		}
+	}
+	if hasSink(sinkStdout) {
+		out = os.Stderr
+		reporter.Output = os.Stderr
+		reporter.Colors = reporter.ColorsEnabled(os.Stderr) && !*noColor
+	}
+
+	// validate inputs firstly
+	expectedArgs := 1
+	if hasSink(sinkFile) || len(args) == 2 && args[1] == "-" {
+		expectedArgs = 2
+	}
+	if len(args) != expectedArgs {
+		panic(fmt.Errorf("invalid number of arguments, %d expected, got %d", expectedArgs, len(args)))
+	}
+
+	numEventsStr := args[0]
+	minEvents, maxEvents, err := parseCount(numEventsStr)
+	if err != nil {
+		// computed counts are passed by scripts, so invalid one is a usage error like invalid flag
+		fmt.Fprintf(os.Stderr, "invalid number of events : %+v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	// count of a range is drawn once, so it's reproducible with the seed
+	numEvents := minEvents
+	if maxEvents > minEvents {
+		seedRandom()
+		numEvents += rand.Intn(maxEvents - minEvents + 1)
+	}
+
+	var outPutFile string
+	if len(args) == 2 {
+		outPutFile = args[1]
+	}
+
+	if *pretty && *format != formatJSON {
+		panic(fmt.Errorf("pretty output is supported for %s format only, %s must keep event per line", formatJSON, formatNDJSON))
+	}
+	if *pretty && maxEvents > maxPrettyEvents {
+		panic(fmt.Errorf("pretty output is limited to %s events, got %s", reporter.FormatNumber(maxPrettyEvents), reporter.FormatNumber(maxEvents)))
+	}
+
+	if *appendArray && (*format != formatJSON || *appendOut) {
+		panic(fmt.Errorf("append array mode is supported for %s format only, use -append for %s and %s", formatJSON, formatNDJSON, formatCSV))
+	}
+
+	switch *format {
+	case formatJSON:
+		if *appendOut {
+			panic(fmt.Errorf("append mode is not supported for %s format, use -append-array, %s or %s", formatJSON, formatNDJSON, formatCSV))
+		}
+		if *rate > 0 {
+			panic(fmt.Errorf("rate is not supported for %s format, use %s or %s", formatJSON, formatNDJSON, formatCSV))
+		}
+	case formatCDR:
+		if *appendOut {
+			panic(fmt.Errorf("append mode is not supported for %s format as records are followed by trailer", formatCDR))
+		}
+		if *fields != "" {
+			panic(fmt.Errorf("fields projection is not supported for %s format as its layout is fixed", formatCDR))
+		}
+	case formatNDJSON, formatCSV:
+	default:
+		panic(fmt.Errorf("unknown output format '%s'", *format))
+	}
+
+	if *dist != "" {
+		distribution, err = generator.ParseDistribution(*dist)
+		if err != nil {
+			panic(fmt.Errorf("invalid event types distribution : %+v", err))
+		}
+	}
+
+	mode, err := strconv.ParseUint(*perm, 8, 32)
+	if err != nil || os.FileMode(mode)&^os.ModePerm != 0 {
+		panic(fmt.Errorf("invalid output file permissions '%s', octal like 0644 expected", *perm))
+	}
+	fileMode = os.FileMode(mode)
+
+	if *manifest && (!hasSink(sinkFile) || *appendOut || *appendArray || *maxFileSize != "") {
+		panic(fmt.Errorf("manifest is supported for a single output file written from scratch only"))
+	}
+
+	bufferSize, err := parseSize(*bufferSizeFlag)
+	if err != nil || bufferSize > math.MaxInt32 {
+		panic(fmt.Errorf("invalid buffer size '%s', positive size like 64KB expected", *bufferSizeFlag))
+	}
+	generator.BufferSize = int(bufferSize)
+
+	if *maxFileSize != "" {
+		if *format == formatJSON || *appendOut || *appendArray || !hasSink(sinkFile) {
+			panic(fmt.Errorf("max file size is supported for %s and %s formats written to new files only", formatNDJSON, formatCSV))
+		}
+		maxSize, err = parseSize(*maxFileSize)
+		if err != nil {
+			panic(fmt.Errorf("invalid max file size : %+v", err))
+		}
+	}
+
+	location, err := time.LoadLocation(*tz)
+	if err != nil {
+		panic(fmt.Errorf("unable to load time zone '%s' : %+v", *tz, err))
+	}
+	generator.Location = location
+
+	if *since != "" || *until != "" {
+		generator.Since = time.Date(2010, 1, 1, 0, 0, 0, 0, location)
+		generator.Until = time.Date(2021, 1, 1, 0, 0, 0, 0, location)
+		if *since != "" {
+			if generator.Since, err = generator.ParseDate(*since); err != nil {
+				panic(fmt.Errorf("invalid since : %+v", err))
+			}
+		}
+		if *until != "" {
+			if generator.Until, err = generator.ParseDate(*until); err != nil {
+				panic(fmt.Errorf("invalid until : %+v", err))
+			}
+		}
+		if !generator.Since.Before(generator.Until) {
+			panic(fmt.Errorf("since %v must be before until %v", generator.Since, generator.Until))
+		}
+	}
+
+	if *perMonth != "" {
+		if *since != "" || *until != "" {
+			panic(fmt.Errorf("per month counts can't be combined with since and until"))
+		}
+		// months define dates on their own, shape would be silently ignored
+		if *trafficShape != generator.ShapeUniform {
+			panic(fmt.Errorf("per month counts can't be combined with %s traffic shape", *trafficShape))
+		}
+		months, err = generator.ParseMonthCounts(*perMonth)
+		if err != nil {
+			panic(fmt.Errorf("invalid per month counts : %+v", err))
+		}
+	}
+
+	if *peakHours != "" {
+		from, to, err := generator.ParseHourWindow(*peakHours)
+		if err != nil {
+			panic(fmt.Errorf("unable to parse peak hours : %+v", err))
+		}
+		generator.HourWeights, err = generator.PeakHourWeights(from, to, *peakFactor)
+		if err != nil {
+			panic(fmt.Errorf("invalid peak hours : %+v", err))
+		}
+	}
+
+	if *trafficShape == generator.ShapeDiurnal && *peakHours != "" {
+		panic(fmt.Errorf("peak hours can't be combined with %s traffic shape", generator.ShapeDiurnal))
+	}
+	if err := generator.SetTrafficShape(*trafficShape); err != nil {
+		panic(fmt.Errorf("invalid traffic shape : %+v", err))
+	}
+
+	if *fields != "" {
+		if hasSink(sinkKafka) {
+			panic(fmt.Errorf("fields projection is not supported by %s sink", sinkKafka))
+		}
+		projection, err = model.NewProjection(strings.Split(*fields, ","))
+		if err != nil {
+			panic(fmt.Errorf("invalid fields : %+v", err))
+		}
+	}
+
+	if *attrCardinality != "" {
+		cardinality, err := generator.ParseCardinality(*attrCardinality)
+		if err != nil {
+			panic(fmt.Errorf("invalid attributes cardinality : %+v", err))
+		}
+		for attr, size := range cardinality {
+			generator.AttributeStrategies[attr-1] = generator.Pool(generator.AttributeStrategies[attr-1], size)
+		}
+	}
+
+	if *nullProb < 0 || *nullProb > 1 {
+		panic(fmt.Errorf("invalid null probability %v, value from 0.0 to 1.0 expected", *nullProb))
+	}
+	if *nullProb > 0 {
+		for i, strategy := range generator.AttributeStrategies {
+			generator.AttributeStrategies[i] = generator.Nullable(strategy, *nullProb)
+		}
+	}
+
+	if *sessionFraction < 0 || *sessionFraction > 1 || *sessionParts < 2 {
+		panic(fmt.Errorf("invalid sessions %v with up to %d parts, fraction from 0.0 to 1.0 and at least 2 parts expected", *sessionFraction, *sessionParts))
+	}
+
+	if *sourceMax <= 0 || *numberMax <= 0 {
+		panic(fmt.Errorf("source and number max must be positive, got %d and %d", *sourceMax, *numberMax))
+	}
+	generator.SourceMax, generator.NumberMax = *sourceMax, *numberMax
+
+	if *fastUUID {
+		generator.EnableFastRefs()
+	}
+
+	switch *durationUnit {
+	case "s":
+	case "ms":
+		generator.MillisDurations = true
+	default:
+		panic(fmt.Errorf("unknown duration unit '%s', s or ms expected", *durationUnit))
+	}
+
+	if *durations != "" {
+		generator.DurationProfiles, err = generator.ParseDurationProfiles(*durations)
+		if err != nil {
+			panic(fmt.Errorf("invalid duration profiles : %+v", err))
+		}
+	}
 
-	// log time duration on application shutdown
+	switch *jsonCase {
+	case "snake":
+	case "camel":
+		if *format != formatJSON && *format != formatNDJSON || hasSink(sinkKafka) {
+			panic(fmt.Errorf("camel case is supported for %s and %s formats written to file or stdout only", formatJSON, formatNDJSON))
+		}
+		if projection == nil {
+			names := model.FieldNames()
+			// millis are not a CSV column, so they are not in field names
+			if generator.MillisDurations {
+				names = append(names, "duration_millis")
+			}
+			projection, err = model.NewProjection(names)
+			if err != nil {
+				panic(fmt.Errorf("unable to create projection : %+v", err))
+			}
+		}
+		projection = projection.CamelCase()
+	default:
+		panic(fmt.Errorf("unknown json case '%s', snake or camel expected", *jsonCase))
+	}
+
+	if *locationLen < 0 || *locationAlphabet == "" {
+		panic(fmt.Errorf("location length must not be negative and alphabet must not be empty"))
+	}
+	for _, r := range *locationAlphabet {
+		if r > unicode.MaxASCII {
+			panic(fmt.Errorf("location alphabet must be ASCII, got '%c'", r))
+		}
+	}
+	if *locationLen > 0 || isFlagSet("location-alphabet") {
+		generator.LocationCode = generator.Codes(*locationLen, *locationAlphabet)
+	}
+
+	if *fraudRate < 0 || *fraudRate > 1 {
+		panic(fmt.Errorf("invalid fraud rate %v, value from 0.0 to 1.0 expected", *fraudRate))
+	}
+	if *fraudPrefixes != "" {
+		prefixes, err := generator.ParsePrefixes(*fraudPrefixes)
+		if err != nil {
+			panic(fmt.Errorf("invalid fraud prefixes : %+v", err))
+		}
+		if err := generator.CheckPrefixes(prefixes, generator.NumberMax); err != nil {
+			panic(fmt.Errorf("invalid fraud prefixes : %+v", err))
+		}
+		eventOptions = append(eventOptions, generator.WithFraudPrefixes(prefixes, *fraudRate))
+	}
+
+	if *correlate {
+		eventOptions = append(eventOptions, generator.WithCorrelatedLocation())
+	}
+
+	if *deriveRules != "" {
+		rules, err := generator.ParseDeriveRules(*deriveRules)
+		if err != nil {
+			panic(fmt.Errorf("invalid derive rules : %+v", err))
+		}
+		eventOptions = append(eventOptions, generator.WithDerivedAttributes(rules))
+	}
+
+	if *lateRate != 0 {
+		var err error
+		late, err = generator.NewLateArrivals(*lateRate, *lateDelay)
+		if err != nil {
+			panic(fmt.Errorf("invalid late arrivals : %+v", err))
+		}
+	}
+
+	// corruption is the last option, so other options don't fix corrupted fields
+	if *corruptRate != 0 {
+		corruption, err := generator.WithCorruption(*corruptKind, *corruptRate)
+		if err != nil {
+			panic(fmt.Errorf("invalid corruption : %+v", err))
+		}
+		eventOptions = append(eventOptions, corruption)
+	}
+
+	if _, err := os.Stat(outPutFile); hasSink(sinkFile) && err == nil && !*appendOut && !*appendArray {
+		fmt.Fprintf(out, "warning: output file %s exists and will be overwritten\n", outPutFile)
+	}
+
+	fmt.Fprintf(out, "number event : %d\n", numEvents)
+	if numEvents == 0 {
+		fmt.Fprintln(out, "no events to generate, empty output is written")
+	}
+	for _, name := range sinks {
+		if name == sinkFile {
+			fmt.Fprintf(out, "dump output: %s\n", outPutFile)
+		} else {
+			fmt.Fprintf(out, "dump output: %s sink\n", name)
+		}
+	}
+
+	if *metricsAddr != "" {
+		jobMetrics = metrics.New("generator")
+		if err := jobMetrics.Serve(*metricsAddr); err != nil {
+			panic(fmt.Errorf("unable to start metrics server : %+v", err))
+		}
+		defer jobMetrics.Shutdown()
+	}
+
+	// stop generation gracefully on interruption
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *bench > 0 {
+		runBenchmark(ctx, *bench, numEvents, outPutFile)
+		return
+	}
+
+	// log time duration on application shutdown, throughput is measured by actually generated events
+	var generated int
 	start := time.Now()
 	defer func() {
-		fmt.Println("================")
-		fmt.Printf("Execution Time : %v\n", time.Since(start))
+		elapsed := time.Since(start)
+		fmt.Fprintln(out, "================")
+		fmt.Fprintf(out, "Execution Time : %v\n", elapsed)
+		fmt.Fprintf(out, "Throughput : %s events/sec\n", reporter.FormatNumber(int(float64(generated)/elapsed.Seconds())))
+
+		// interrupted runs are not comparable with the complete ones and empty runs measure nothing
+		if ctx.Err() != nil || numEvents == 0 {
+			return
+		}
+
+		err := reporter.SaveAndReport(*statsFile, newStatistic(start, numEvents, elapsed))
+		if err != nil {
+			panic(fmt.Errorf("unable to save execution statistic : %+v", err))
+		}
 	}()
 
-	// validate inputs firstly
-	if len(os.Args) != 3 {
-		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", len(os.Args)-1))
+	seedRandom()
+	var counts map[model.EventType]int
+	generated, counts = generate(ctx, numEvents, outPutFile)
+	if generated < numEvents {
+		fmt.Fprintf(out, "generation cancelled, %d of %d events generated and saved\n", generated, numEvents)
 	}
 
-	numEventsStr := os.Args[1]
-	numEvents, err := strconv.Atoi(numEventsStr)
-	if err != nil {
-		panic(fmt.Errorf("unable to parse number of events : %+v", err))
+	if *manifest && generated == numEvents {
+		m, err := generator.WriteManifest(outPutFile, generated)
+		if err != nil {
+			panic(fmt.Errorf("unable to write manifest : %+v", err))
+		}
+		fmt.Fprintf(out, "manifest : %s (sha256 %s)\n", generator.ManifestName(outPutFile), m.SHA256)
+	}
+
+	if *breakdown || *validateDist {
+		reporter.PrintEventTypeBreakdown(out, distribution.Percentages(), reporter.CountsBreakdown(counts))
+	}
+	// no events could match distribution
+	if *validateDist && generated > 0 {
+		err := reporter.CheckDistribution(distribution.Percentages(), reporter.CountsBreakdown(counts), *distTolerance)
+		if err != nil {
+			panic(fmt.Errorf("generated events don't match distribution : %+v", err))
+		}
+		fmt.Fprintf(out, "event types match distribution within %.2f%%\n", *distTolerance)
+	}
+}
+
+// runBenchmark will run generation provided number of times, save statistic of every run
+// and print aggregated statistics at the end.
+// Benchmark stops on context cancellation, interrupted run is not saved.
+func runBenchmark(ctx context.Context, runs int, numEvents int, outPutFile string) {
+	stats := make([]reporter.ExecutionStatistic, 0, runs)
+
+	for i := 0; i < runs; i++ {
+		// every run must start from the clean state
+		seedRandom()
+
+		start := time.Now()
+		generated, _ := generate(ctx, numEvents, outPutFile)
+		if generated < numEvents {
+			fmt.Fprintf(out, "benchmark cancelled on run %d/%d, %d of %d events generated\n", i+1, runs, generated, numEvents)
+			break
+		}
+
+		stat := newStatistic(start, numEvents, time.Since(start))
+		if err := reporter.Save(*statsFile, stat); err != nil {
+			panic(fmt.Errorf("unable to save execution statistic : %+v", err))
+		}
+		stats = append(stats, stat)
+
+		fmt.Fprintf(out, "run %d/%d : %v\n", i+1, runs, stat.Duration)
+	}
+
+	if len(stats) == 0 {
+		return
 	}
 
-	outPutFile := os.Args[2]
+	summary := reporter.Summarize(stats)
+	fmt.Fprintln(out, "================")
+	fmt.Fprintf(out, "Runs   : %d\n", summary.Runs)
+	fmt.Fprintf(out, "Mean   : %v\n", summary.Mean)
+	fmt.Fprintf(out, "Median : %v\n", summary.Median)
+	fmt.Fprintf(out, "Min    : %v\n", summary.Min)
+	fmt.Fprintf(out, "Max    : %v\n", summary.Max)
+}
 
-	fmt.Printf("number event : %d\n", numEvents)
-	fmt.Printf("dump output: %s\n", outPutFile)
+// newStatistic creates execution statistic of the run with commit and host it was executed on.
+func newStatistic(start time.Time, numEvents int, duration time.Duration) reporter.ExecutionStatistic {
+	// hostname is optional metadata, so run is recorded even if it's unknown
+	hostname, _ := os.Hostname()
+	return reporter.ExecutionStatistic{
+		ExecutionStart: start,
+		NumbOfEvents:   numEvents,
+		Duration:       duration,
+		GitCommit:      gitCommit,
+		Hostname:       hostname,
+	}
+}
 
-	// act
-	events := []*model.Event{}
+// isFlagSet reports whether flag with provided name is set in command line.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
 
-	// generate requested number of events
-	for i := 0; i < numEvents; i++ {
-		events = append(events, generateEvent())
+// seedRandom will seed global random generator with provided seed or with a new one if seed is not set.
+func seedRandom() {
+	if *seed != 0 {
+		rand.Seed(*seed)
+		return
 	}
+	rand.Seed(time.Now().UnixNano())
+}
 
-	// marshall for saving
-	content, err := json.Marshal(events)
+// newDateGenerator creates generator of event dates configured by flags.
+func newDateGenerator() generator.DateGenerator {
+	switch {
+	case len(months) > 0:
+		return &generator.PerMonthDateGen{Months: months}
+	case *trafficShape == generator.ShapeBursty:
+		return &generator.BurstyDateGen{Since: generator.Since, Until: generator.Until}
+	case *trafficShape == generator.ShapeDiurnal:
+		return generator.DiurnalDateGen{Since: generator.Since, Until: generator.Until}
+	case *peakHours != "":
+		return generator.PeakHoursDateGen{Since: generator.Since, Until: generator.Until, Weights: generator.HourWeights}
+	}
+	return generator.UniformDateGen{Since: generator.Since, Until: generator.Until}
+}
+
+// parseCount parses number of events, either fixed like 1000 or range like 1000-5000.
+// Returns min and max number of events, they are equal for fixed number.
+func parseCount(s string) (int, int, error) {
+	if strings.HasPrefix(s, "-") {
+		return 0, 0, fmt.Errorf("number of events must not be negative, got %s", s)
+	}
+
+	from, to, isRange := strings.Cut(s, "-")
+	min, err := strconv.Atoi(from)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, 0, fmt.Errorf("number of events %s is too large, at most %d expected", from, math.MaxInt)
+	}
 	if err != nil {
-		panic(fmt.Errorf("unable to marshall events : %+v", err))
+		return 0, 0, err
+	}
+	if !isRange {
+		return min, min, nil
 	}
 
-	// and write everything
-	err = ioutil.WriteFile(outPutFile, content, 0777)
+	max, err := strconv.Atoi(to)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, 0, fmt.Errorf("number of events %s is too large, at most %d expected", to, math.MaxInt)
+	}
 	if err != nil {
-		panic(fmt.Errorf("unable to write file : %+v", err))
+		return 0, 0, fmt.Errorf("invalid range '%s', range like 1000-5000 expected : %w", s, err)
+	}
+	if min > max {
+		return 0, 0, fmt.Errorf("invalid range '%s', min %d is greater than max %d", s, min, max)
 	}
+	return min, max, nil
 }
 
-// generateEvent will create a new instance of event with some random values.
-func generateEvent() *model.Event {
-	return &model.Event{
-		EventSource:     rand.Intn(88005553535),
-		EventRef:        uuid.New().String(),
-		EventType:       generateEventType(),
-		EventDate:       *generator.RandomDate(),
-		CallingNumber:   rand.Intn(88005553535),
-		CalledNumber:    rand.Intn(88005553535),
-		Location:        generator.RandomString(),
-		DurationSeconds: rand.Intn(100),
-		Attr1:           generator.RandomString(),
-		Attr2:           generator.RandomString(),
-		Attr3:           generator.RandomString(),
-		Attr4:           generator.RandomString(),
-		Attr5:           generator.RandomString(),
-		Attr6:           generator.RandomString(),
-		Attr7:           generator.RandomString(),
-		Attr8:           generator.RandomString(),
+// generate will create requested number of events and write them to provided file.
+// If context is cancelled generation stops and already generated events are written.
+// Returns number of written events and their numbers by event type,
+// events are streamed to the sink in every format and kept in memory only if they are sorted before writing.
+func generate(ctx context.Context, numEvents int, outPutFile string) (int, map[model.EventType]int) {
+	var events model.Events
+	written := 0
+	counts := map[model.EventType]int{}
+	refs, err := generator.NewRefGenerator(*refVersion, *checkUniq)
+	if err != nil {
+		panic(fmt.Errorf("unable to create ref generator : %+v", err))
 	}
-}
 
-// generateEventType will generate event type with following probability;
-// * type 1 - 15%
-// * type 2 - 20%
-// * type 3 - 20&
-// * type 5 - 45%
-func generateEventType() int {
-	r := rand.Intn(100)
+	sink, err := openSink(outPutFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open sink : %+v", err))
+	}
+	flusher, _ := sink.(generator.Flusher)
 
-	if r < 15 {
-		return 1
-	} else if r < 35 {
-		return 2
-	} else if r < 55 {
-		return 3
+	if projection != nil {
+		projected, ok := sink.(interface{ SetProjection(p *model.Projection) })
+		if !ok {
+			panic(fmt.Errorf("fields projection is not supported by %s sink", *sinkType))
+		}
+		projected.SetProjection(projection)
 	}
-	return 5
+
+	if *pretty {
+		indented, ok := sink.(interface{ SetIndent(indent string) })
+		if !ok {
+			panic(fmt.Errorf("pretty output is not supported by %s sink", *sinkType))
+		}
+		indented.SetIndent("  ")
+	}
+
+	var sessions *generator.Sessions
+	if *sessionFraction > 0 {
+		sessions, err = generator.NewSessions(*sessionFraction, *sessionParts)
+		if err != nil {
+			panic(fmt.Errorf("invalid sessions : %+v", err))
+		}
+	}
+
+	dates := newDateGenerator()
+
+	var p *pacer
+	if *rate > 0 {
+		p = newPacer(*rate)
+	}
+
+	batcher := metrics.NewBatcher(jobMetrics, metricsBatchSize)
+	// random source is seeded and sink is open, so generation is ready to serve events
+	jobMetrics.SetReady(true)
+	defer jobMetrics.SetReady(false)
+
+	lateEvents := 0
+	// write reports whether generation should continue
+	write := func(e *model.Event) bool {
+		if late != nil && late.Delay(e) {
+			lateEvents++
+		}
+		if err := sink.Write(e); err != nil {
+			panic(fmt.Errorf("unable to write event : %+v", err))
+		}
+		batcher.Inc()
+		written++
+		counts[e.EventType]++
+
+		// paced events must reach the reader immediately
+		if p != nil {
+			if flusher != nil {
+				if err := flusher.Flush(); err != nil {
+					panic(fmt.Errorf("unable to flush sink : %+v", err))
+				}
+			}
+			return p.Wait(ctx)
+		}
+		return true
+	}
+
+	// generate requested number of events, sorted events are written once all of them are generated
+	for i := 0; i < numEvents && ctx.Err() == nil; i++ {
+		e := generator.RandomEvent(generator.Options{Refs: refs, Distribution: distribution, Dates: dates, Sessions: sessions, Events: eventOptions})
+		if *sortEvents {
+			events = append(events, e)
+			continue
+		}
+		if !write(e) {
+			break
+		}
+	}
+	if *sortEvents {
+		events.SortByDate()
+		for _, e := range events {
+			if ctx.Err() != nil || !write(e) {
+				break
+			}
+		}
+	}
+	batcher.Flush()
+
+	if err := sink.Close(); err != nil {
+		panic(fmt.Errorf("unable to write events : %+v", err))
+	}
+
+	if late != nil {
+		fmt.Fprintf(out, "late events : %d\n", lateEvents)
+	}
+
+	if rolling, ok := sink.(*generator.RollingFileSink); ok {
+		fmt.Fprintf(out, "events are written to %d files : %s\n", len(rolling.Files()), strings.Join(rolling.Files(), ", "))
+	}
+
+	return written, counts
 }
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// argsEnv passes generator arguments to the test binary re-executed as generator.
+const argsEnv = "GENERATOR_TEST_ARGS"
+
+// runGenerator runs generator with provided arguments in a separate process, so its exit code could be checked.
+// Returns output of the process and its exit code.
+func runGenerator(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=TestGeneratorProcess")
+	cmd.Env = append(os.Environ(), argsEnv+"="+strings.Join(args, "\n"))
+	cmd.Dir = t.TempDir()
+	output, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(output), exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatalf("unable to run generator : %v", err)
+	}
+	return string(output), 0
+}
+
+// TestGeneratorProcess runs generator main when the test binary is executed by runGenerator.
+func TestGeneratorProcess(t *testing.T) {
+	args, ok := os.LookupEnv(argsEnv)
+	if !ok {
+		t.Skip("executed by runGenerator only")
+	}
+	os.Args = append([]string{"generator"}, strings.Split(args, "\n")...)
+	main()
+	os.Exit(0)
+}
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		input    string
+		min, max int
+		valid    bool
+	}{
+		{input: "0", valid: true},
+		{input: "1000", min: 1000, max: 1000, valid: true},
+		{input: "1000-5000", min: 1000, max: 5000, valid: true},
+		{input: "0-0", valid: true},
+		{input: fmt.Sprint(math.MaxInt), min: math.MaxInt, max: math.MaxInt, valid: true},
+		{input: "-1"},
+		{input: "-1000-5000"},
+		{input: "5000-1000"},
+		{input: "1000-"},
+		{input: "1000--5"},
+		{input: "99999999999999999999"},
+		{input: "1000-99999999999999999999"},
+		{input: "1e6"},
+		{input: ""},
+	}
+
+	for _, test := range tests {
+		min, max, err := parseCount(test.input)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("expected '%s' to be rejected, got %d-%d", test.input, min, max)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unable to parse '%s' : %v", test.input, err)
+		} else if min != test.min || max != test.max {
+			t.Errorf("'%s' : expected %d-%d, got %d-%d", test.input, test.min, test.max, min, max)
+		}
+	}
+}
+
+func TestInvalidCountExitsWithUsageError(t *testing.T) {
+	for _, count := range []string{"-5", "99999999999999999999", "many"} {
+		// flags end before count, so negative count is not parsed as a flag
+		output, code := runGenerator(t, "--", count, "events.json")
+		if code != 2 {
+			t.Errorf("%s : expected exit code 2, got %d, output:\n%s", count, code, output)
+		}
+		if !strings.Contains(output, "invalid number of events") {
+			t.Errorf("%s : expected invalid number of events error, got:\n%s", count, output)
+		}
+	}
+}
+
+func TestZeroCountWritesEmptyArray(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "events.json")
+
+	output, code := runGenerator(t, "-stats", filepath.Join(dir, "stats.json"), "0", filename)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d, output:\n%s", code, output)
+	}
+	if !strings.Contains(output, "number event : 0") {
+		t.Errorf("expected zero events to be reported, got:\n%s", output)
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read output : %v", err)
+	}
+	if strings.TrimSpace(string(content)) != "[]" {
+		t.Errorf("expected empty array, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stats.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected empty run not to be saved to statistics, got %v", err)
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	stats := filepath.Join(dir, "stats.json")
+
+	// writeConfig writes config of 3 ndjson events written to provided output
+	writeConfig := func(output string) string {
+		config := output + ".yaml"
+		content := fmt.Sprintf("count: 3\nformat: ndjson\noutput: %s\n", output)
+		if err := os.WriteFile(config, []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write config : %v", err)
+		}
+		return config
+	}
+
+	tests := []struct {
+		name string
+		args func(output string) []string
+		// prefix is the beginning of output in expected format.
+		prefix string
+	}{
+		{
+			name:   "default",
+			args:   func(output string) []string { return []string{"3", output} },
+			prefix: "[",
+		},
+		{
+			name:   "file over default",
+			args:   func(output string) []string { return []string{"-config", writeConfig(output)} },
+			prefix: "{",
+		},
+		{
+			name:   "flag over file",
+			args:   func(output string) []string { return []string{"-format", "csv", "-config", writeConfig(output)} },
+			prefix: "event_source,",
+		},
+	}
+
+	for _, test := range tests {
+		output := filepath.Join(dir, strings.ReplaceAll(test.name, " ", "_"))
+		args := append([]string{"-stats", stats}, test.args(output)...)
+		if out, code := runGenerator(t, args...); code != 0 {
+			t.Fatalf("%s : expected exit code 0, got %d, output:\n%s", test.name, code, out)
+		}
+
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%s : unable to read output : %v", test.name, err)
+		}
+		if !strings.HasPrefix(string(content), test.prefix) {
+			t.Errorf("%s : expected output starting with %s, got:\n%s", test.name, test.prefix, content)
+		}
+	}
+}
+
+// generatedCount returns number of events reported by generator run with provided seed and count.
+func generatedCount(t *testing.T, seed int, count string) int {
+	t.Helper()
+	dir := t.TempDir()
+	output, code := runGenerator(t, "-seed", strconv.Itoa(seed), "-format", "ndjson", "-stats", filepath.Join(dir, "stats.json"), count, filepath.Join(dir, "events.ndjson"))
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d, output:\n%s", code, output)
+	}
+
+	var n int
+	for _, line := range strings.Split(output, "\n") {
+		if _, err := fmt.Sscanf(line, "number event : %d", &n); err == nil {
+			break
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "events.ndjson"))
+	if err != nil {
+		t.Fatalf("unable to read output : %v", err)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != n {
+		t.Fatalf("expected %d reported events to be written, got %d, output:\n%s", n, lines, output)
+	}
+	return n
+}
+
+func TestCountRangeBounds(t *testing.T) {
+	counts := map[int]bool{}
+	for seed := 1; seed <= 10; seed++ {
+		n := generatedCount(t, seed, "10-20")
+		if n < 10 || n > 20 {
+			t.Errorf("seed %d : expected count within 10-20, got %d", seed, n)
+		}
+		counts[n] = true
+
+		// count is reproducible with the seed
+		if again := generatedCount(t, seed, "10-20"); again != n {
+			t.Errorf("seed %d : expected the same count %d, got %d", seed, n, again)
+		}
+	}
+	if len(counts) < 2 {
+		t.Errorf("expected counts to vary with seed, got %v", counts)
+	}
+
+	if n := generatedCount(t, 1, "7-7"); n != 7 {
+		t.Errorf("expected count 7 of single value range, got %d", n)
+	}
+}
+
+func TestStdoutPipe(t *testing.T) {
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=TestGeneratorProcess")
+	cmd.Env = append(os.Environ(), argsEnv+"="+strings.Join([]string{"-stats", filepath.Join(dir, "stats.json"), "5", "-"}, "\n"))
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("unable to pipe standard output : %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("unable to run generator : %v", err)
+	}
+
+	// events are decoded from the pipe the way loader reads standard input
+	var events model.Events
+	_, streamErr := generator.StreamEvents(stdout, generator.FormatNDJSON, func(e *model.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("generator failed : %v, output:\n%s", err, stderr.String())
+	}
+	if streamErr != nil {
+		t.Fatalf("unable to read piped events : %v", streamErr)
+	}
+
+	if len(events) != 5 {
+		t.Errorf("expected 5 piped events, got %d", len(events))
+	}
+	for _, e := range events {
+		if err := e.Validate(); err != nil {
+			t.Errorf("expected valid piped event : %v", err)
+		}
+	}
+	// progress and timing go to standard error, so they don't break the stream
+	for _, line := range []string{"number event : 5", "Execution Time"} {
+		if !strings.Contains(stderr.String(), line) {
+			t.Errorf("expected %s in standard error, got:\n%s", line, stderr.String())
+		}
+	}
+}
+
+func TestSinceUntilValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "equal bounds", args: []string{"-since", "2022-03-01", "-until", "2022-03-01"}, expected: "must be before until"},
+		{name: "reversed bounds", args: []string{"-since", "2022-04-01", "-until", "2022-03-01"}, expected: "must be before until"},
+		{name: "since after default until", args: []string{"-since", "2022-03-01"}, expected: "must be before until"},
+		{name: "malformed since", args: []string{"-since", "March"}, expected: "invalid since"},
+		{name: "malformed until", args: []string{"-until", "2022-13-01"}, expected: "invalid until"},
+	}
+
+	for _, test := range tests {
+		dir := t.TempDir()
+		args := append(test.args, "-stats", filepath.Join(dir, "stats.json"), "10", filepath.Join(dir, "events.json"))
+		output, code := runGenerator(t, args...)
+		if code == 0 {
+			t.Errorf("%s : expected generator to fail, output:\n%s", test.name, output)
+		} else if !strings.Contains(output, test.expected) {
+			t.Errorf("%s : expected %q in output, got:\n%s", test.name, test.expected, output)
+		}
+	}
+}
+
+func TestFraudPrefixesAboveNumberMax(t *testing.T) {
+	dir := t.TempDir()
+	output, code := runGenerator(t, "-fraud-prefixes", "900", "-number-max", "1000000", "-stats", filepath.Join(dir, "stats.json"), "10", filepath.Join(dir, "events.json"))
+	if code == 0 {
+		t.Errorf("expected generator to fail, output:\n%s", output)
+	} else if !strings.Contains(output, "invalid fraud prefixes") {
+		t.Errorf("expected invalid fraud prefixes in output, got:\n%s", output)
+	}
+}
+
+func TestSinceUntilBoundEventDates(t *testing.T) {
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "events.ndjson")
+	output, code := runGenerator(t, "-since", "2022-03-01", "-until", "2022-04-01T00:00:00Z", "-format", "ndjson",
+		"-stats", filepath.Join(dir, "stats.json"), "1000", filename)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d, output:\n%s", code, output)
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read output : %v", err)
+	}
+	events, err := generator.ReadNDJSON(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("unable to read events : %v", err)
+	}
+	if len(events) != 1000 {
+		t.Fatalf("expected 1000 events, got %d", len(events))
+	}
+	for _, e := range events {
+		if e.EventDate.Before(since) || !e.EventDate.Before(until) {
+			t.Fatalf("expected event date within [%v, %v), got %v", since, until, e.EventDate)
+		}
+	}
+}
+
+func TestOutputFilePermissions(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		// allowed are permissions the file may have, umask may only drop some of them.
+		allowed os.FileMode
+		// required are permissions the file must have.
+		required os.FileMode
+	}{
+		{name: "default", allowed: 0644, required: 0600},
+		{name: "owner only", args: []string{"-perm", "0600"}, allowed: 0600, required: 0600},
+		{name: "group writable", args: []string{"-perm", "660"}, allowed: 0660, required: 0600},
+	}
+
+	for _, test := range tests {
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "events.json")
+		args := append(test.args, "-stats", filepath.Join(dir, "stats.json"), "10", filename)
+		if output, code := runGenerator(t, args...); code != 0 {
+			t.Fatalf("%s : expected exit code 0, got %d, output:\n%s", test.name, code, output)
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatalf("unable to stat output : %v", err)
+		}
+		if mode := info.Mode().Perm(); mode&^test.allowed != 0 || mode&test.required != test.required {
+			t.Errorf("%s : expected mode within %v, got %v", test.name, test.allowed, mode)
+		}
+	}
+}
+
+func TestInvalidOutputFilePermissions(t *testing.T) {
+	for _, perm := range []string{"0999", "rw-r--r--", "01644", "-1"} {
+		dir := t.TempDir()
+		output, code := runGenerator(t, "-perm", perm, "-stats", filepath.Join(dir, "stats.json"), "10", filepath.Join(dir, "events.json"))
+		if code == 0 || !strings.Contains(output, "invalid output file permissions") {
+			t.Errorf("expected permissions '%s' to be rejected, got exit code %d, output:\n%s", perm, code, output)
+		}
+	}
+}
+
+func TestTimeZoneOfPeakHours(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "events.ndjson")
+	output, code := runGenerator(t, "-tz", "Europe/Kyiv", "-peak-hours", "9-12", "-peak-factor", "1000",
+		"-since", "2022-06-01", "-until", "2022-07-01", "-format", "ndjson", "-stats", filepath.Join(dir, "stats.json"), "2000", filename)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d, output:\n%s", code, output)
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read output : %v", err)
+	}
+	events, err := generator.ReadNDJSON(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("unable to read events : %v", err)
+	}
+
+	peak := 0
+	for _, e := range events {
+		// summer time of Kyiv is UTC+3
+		if _, offset := e.EventDate.Zone(); offset != 3*60*60 {
+			t.Fatalf("expected event date in Kyiv summer time, got %v", e.EventDate)
+		}
+		if hour := e.EventDate.Hour(); hour >= 9 && hour < 12 {
+			peak++
+		}
+	}
+	// peak hours are local, 99.3% of events are expected within them, they would be 06-09 in UTC otherwise
+	if share := float64(peak) / float64(len(events)); share < 0.97 {
+		t.Errorf("expected events within local peak hours, got %.2f%% of them", share*100)
+	}
+}
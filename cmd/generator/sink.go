@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// supported sinks.
+const (
+	sinkFile   = "file"
+	sinkStdout = "stdout"
+	sinkKafka  = "kafka"
+)
+
+// sinks are names of configured sinks.
+var sinks []string
+
+// parseSinks parses comma separated sink names, e.g. file,kafka.
+func parseSinks(s string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case sinkFile, sinkStdout, sinkKafka:
+		default:
+			return nil, fmt.Errorf("unknown sink '%s'", name)
+		}
+		for _, n := range names {
+			if n == name {
+				return nil, fmt.Errorf("duplicated sink '%s'", name)
+			}
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// hasSink reports whether sink with provided name is configured.
+func hasSink(name string) bool {
+	for _, n := range sinks {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// openSink creates configured sinks of generated events, events are fanned out if multiple sinks are configured.
+func openSink(outPutFile string) (generator.Sink, error) {
+	opened := make([]generator.Sink, 0, len(sinks))
+	for _, name := range sinks {
+		sink, err := openNamedSink(name, outPutFile)
+		if err != nil {
+			for _, s := range opened {
+				s.Close()
+			}
+			return nil, fmt.Errorf("unable to open %s sink : %w", name, err)
+		}
+		opened = append(opened, sink)
+	}
+
+	if len(opened) == 1 {
+		return opened[0], nil
+	}
+	return generator.MultiSink(opened...), nil
+}
+
+// openNamedSink creates sink of provided name.
+func openNamedSink(name, outPutFile string) (generator.Sink, error) {
+	switch name {
+	case sinkFile:
+		if maxSize > 0 {
+			return generator.NewRollingFileSink(outPutFile, *format, maxSize, fileMode)
+		}
+		if *appendArray {
+			return generator.NewArrayAppendSink(outPutFile, fileMode)
+		}
+		return generator.NewFileSink(outPutFile, *format, *appendOut, fileMode)
+	case sinkStdout:
+		return generator.NewStdoutSink(*format)
+	case sinkKafka:
+		var brokers []string
+		for _, broker := range strings.Split(*kafkaBrokers, ",") {
+			if broker = strings.TrimSpace(broker); broker != "" {
+				brokers = append(brokers, broker)
+			}
+		}
+		return generator.NewKafkaSink(brokers, *kafkaTopic)
+	}
+	return nil, fmt.Errorf("unknown sink '%s'", name)
+}
+
+// sizeUnits are multipliers of file size suffixes.
+var sizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// parseSize parses size like 500MB, number without suffix is size in bytes.
+func parseSize(s string) (int64, error) {
+	value, unit := strings.ToUpper(strings.TrimSpace(s)), int64(1)
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(value, u.suffix) {
+			value, unit = strings.TrimSpace(strings.TrimSuffix(value, u.suffix)), u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size '%s', positive size like 500MB expected", s)
+	}
+	return n * unit, nil
+}
+
+// pacer spreads events evenly in time to emit them with configured rate.
+type pacer struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// newPacer creates pacer emitting provided number of events per second.
+func newPacer(rate float64) *pacer {
+	return &pacer{interval: time.Duration(float64(time.Second) / rate), next: time.Now()}
+}
+
+// Wait blocks until the next event is due, returns false if context is cancelled.
+// Deadlines are scheduled from the start, so slow writes don't accumulate drift.
+func (p *pacer) Wait(ctx context.Context) bool {
+	p.next = p.next.Add(p.interval)
+
+	timer := time.NewTimer(time.Until(p.next))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
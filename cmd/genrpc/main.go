@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/genrpc"
+	"google.golang.org/grpc"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var (
+	addr = flag.String("addr", ":9000", "address to serve gRPC generation service on")
+)
+
+// GenRPC will serve gRPC service streaming generated events, so clients written in any language
+// could drive the generator. Service is described by pkg/genrpc/genrpc.proto.
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		panic(fmt.Errorf("unable to listen on %s : %+v", *addr, err))
+	}
+
+	server := grpc.NewServer()
+	genrpc.RegisterGeneratorServer(server, genrpc.NewServer())
+
+	// stop serving gracefully on interruption
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	fmt.Printf("serving generation on %s\n", listener.Addr())
+	if err := server.Serve(listener); err != nil {
+		panic(fmt.Errorf("unable to serve : %+v", err))
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+const (
+	// longAttrsTruncate cuts over-long attributes to the max length.
+	longAttrsTruncate = "truncate"
+	// longAttrsReject skips events with over-long attributes.
+	longAttrsReject = "reject"
+)
+
+// attrLimiter enforces max length of event attributes in characters, so database doesn't truncate or reject them.
+type attrLimiter struct {
+	max    int
+	reject bool
+
+	// truncated is number of events with truncated attributes.
+	truncated int
+	// rejected is number of skipped events.
+	rejected int
+}
+
+// newAttrLimiter creates limiter of attributes longer than max characters handled by provided mode.
+func newAttrLimiter(max int, mode string) (*attrLimiter, error) {
+	switch mode {
+	case longAttrsTruncate, longAttrsReject:
+	default:
+		return nil, fmt.Errorf("unknown long attributes mode '%s', %s or %s expected", mode, longAttrsTruncate, longAttrsReject)
+	}
+	if max <= 0 {
+		return nil, fmt.Errorf("max attribute length must be positive, got %d", max)
+	}
+	return &attrLimiter{max: max, reject: mode == longAttrsReject}, nil
+}
+
+// Accept reports whether event should be loaded, over-long attributes of accepted events are truncated.
+func (l *attrLimiter) Accept(e *model.Event) bool {
+	attrs := e.Attributes()
+	long := false
+	for i, attr := range attrs {
+		if utf8.RuneCountInString(attr) > l.max {
+			long = true
+			attrs[i] = truncate(attr, l.max)
+		}
+	}
+	if !long {
+		return true
+	}
+
+	if l.reject {
+		l.rejected++
+		return false
+	}
+	l.truncated++
+	e.SetAttributes(attrs)
+	return true
+}
+
+// truncate returns first max characters of s.
+func truncate(s string, max int) string {
+	n := 0
+	for i := range s {
+		if n == max {
+			return s[:i]
+		}
+		n++
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// oversizedEvent returns event with the second attribute of provided length and short other attributes.
+func oversizedEvent(length int) *model.Event {
+	return generator.NewEvent(generator.WithRef("ref-1"),
+		generator.WithAttributes([8]string{"short", strings.Repeat("x", length), "", "ąęł"}))
+}
+
+func TestAttrLimiterTruncates(t *testing.T) {
+	l, err := newAttrLimiter(3, longAttrsTruncate)
+	if err != nil {
+		t.Fatalf("unable to create limiter : %v", err)
+	}
+
+	e := oversizedEvent(10 * 1024)
+	if !l.Accept(e) {
+		t.Fatal("expected event with truncated attributes to be accepted")
+	}
+
+	expected := [8]string{"sho", "xxx", "", "ąęł"}
+	if attrs := e.Attributes(); attrs != expected {
+		t.Errorf("expected attributes %q, got %q", expected, attrs)
+	}
+	if l.truncated != 1 || l.rejected != 0 {
+		t.Errorf("expected 1 truncated and 0 rejected events, got %d and %d", l.truncated, l.rejected)
+	}
+
+	// attributes within the limit are kept as is and not counted
+	short := generator.NewEvent(generator.WithAttributes([8]string{"abc", "ąęł"}))
+	if !l.Accept(short) || short.Attributes() != [8]string{"abc", "ąęł"} || l.truncated != 1 {
+		t.Errorf("expected short attributes to be kept, got %q and %d truncated events", short.Attributes(), l.truncated)
+	}
+}
+
+func TestAttrLimiterRejects(t *testing.T) {
+	l, err := newAttrLimiter(1024, longAttrsReject)
+	if err != nil {
+		t.Fatalf("unable to create limiter : %v", err)
+	}
+
+	e := oversizedEvent(1025)
+	if l.Accept(e) {
+		t.Fatal("expected event with oversized attribute to be rejected")
+	}
+	if attr := e.Attributes()[1]; len(attr) != 1025 {
+		t.Errorf("expected rejected event to be kept as is, got attribute of length %d", len(attr))
+	}
+	if !l.Accept(oversizedEvent(1024)) {
+		t.Error("expected event with attribute of max length to be accepted")
+	}
+	if l.truncated != 0 || l.rejected != 1 {
+		t.Errorf("expected 0 truncated and 1 rejected events, got %d and %d", l.truncated, l.rejected)
+	}
+}
+
+func TestNewAttrLimiterValidates(t *testing.T) {
+	if _, err := newAttrLimiter(10, "drop"); err == nil {
+		t.Error("expected unknown mode to be rejected")
+	}
+	if _, err := newAttrLimiter(-1, longAttrsTruncate); err == nil {
+		t.Error("expected negative length to be rejected")
+	}
+}
+
+func TestLoadNDJSONLongLine(t *testing.T) {
+	// line is longer than default buffer of bufio.Scanner
+	content, err := json.Marshal(oversizedEvent(256 * 1024))
+	if err != nil {
+		t.Fatalf("unable to marshall event : %v", err)
+	}
+
+	var events model.Events
+	skipped, err := generator.StreamNDJSON(bytes.NewReader(append(content, '\n')), func(e *model.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read events : %v", err)
+	}
+	if skipped != 0 || len(events) != 1 {
+		t.Fatalf("expected 1 event and no skipped lines, got %d and %d", len(events), skipped)
+	}
+	if attr := events[0].Attributes()[1]; len(attr) != 256*1024 {
+		t.Errorf("expected attribute of length %d, got %d", 256*1024, len(attr))
+	}
+}
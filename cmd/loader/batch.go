@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"strings"
+	"time"
+)
+
+// eventColumns are columns of event table in order of insert arguments.
+const eventColumns = `event_source, event_ref, event_type, event_date, calling_number, called_number, location,
+                  duration_seconds, attr_1, attr_2, attr_3, attr_4, attr_5, attr_6, attr_7, attr_8`
+
+// argsPerEvent is number of bind arguments of a single event, event date is inlined into the query.
+const argsPerEvent = 15
+
+// millisColumn is optional column of durations in milliseconds, it's loaded only if requested.
+const millisColumn = "duration_millis"
+
+// maxRetryBackoff caps exponentially growing delay between retries of a transaction.
+const maxRetryBackoff = 5 * time.Second
+
+// batchLoader accumulates events and saves them to database with a single multi-row insert per batch.
+type batchLoader struct {
+	d      dialect.Dialect
+	tx     *sql.Tx
+	size   int
+	batch  model.Events
+	resume bool
+
+	// loaded is number of events saved to database.
+	loaded int
+	// existing is number of events skipped because they are already stored.
+	existing int
+
+	// db starts new transactions of intermediate commits and retries.
+	db *sql.DB
+	// commitEvery is number of events of a single transaction, the whole load is a single transaction if it's 0.
+	commitEvery int
+	// uncommitted is number of loaded events of the current transaction.
+	uncommitted int
+	// committed is number of events of already committed transactions.
+	committed int
+
+	// retries is number of retries of transaction failed by serialization failure or deadlock, it's not retried if 0.
+	retries int
+	backoff time.Duration
+	// retried is number of retried transactions.
+	retried int
+	// pending are events of the current transaction, they are replayed by retried transaction.
+	pending model.Events
+}
+
+// newBatchLoader creates loader inserting events by batches of provided size within transaction.
+// In resume mode events already stored in database are skipped.
+func newBatchLoader(d dialect.Dialect, tx *sql.Tx, size int, resume bool) *batchLoader {
+	if size < 1 {
+		size = 1
+	}
+	return &batchLoader{d: d, tx: tx, size: size, batch: make(model.Events, 0, size), resume: resume}
+}
+
+// CommitEvery makes loader commit transaction and start a new one once at least n events are loaded by it,
+// transactions are switched between batches. Committed events are kept even if the load fails later.
+func (l *batchLoader) CommitEvery(db *sql.DB, n int) {
+	l.db, l.commitEvery = db, n
+}
+
+// Retry makes loader retry transaction failed by postgres serialization failure or deadlock up to provided
+// number of times, delay between retries starts with backoff and doubles up to maxRetryBackoff.
+// Failed transaction is rolled back and its events are inserted again by a new one, so events of the current
+// transaction are kept in memory, combine it with CommitEvery to bound them.
+func (l *batchLoader) Retry(db *sql.DB, retries int, backoff time.Duration) {
+	l.db, l.retries, l.backoff = db, retries, backoff
+}
+
+// Add will append event to the current batch and save the batch when it's full.
+func (l *batchLoader) Add(ctx context.Context, e *model.Event) error {
+	l.batch = append(l.batch, e)
+	if len(l.batch) >= l.size {
+		return l.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush will save accumulated events to database.
+func (l *batchLoader) Flush(ctx context.Context) error {
+	if len(l.batch) == 0 {
+		return nil
+	}
+	defer func() { l.batch = l.batch[:0] }()
+
+	batch := l.batch
+	if l.resume {
+		var err error
+		batch, err = l.skipExisting(ctx, batch)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+	}
+
+	err := l.insert(ctx, batch)
+	for attempt := 1; err != nil && attempt <= l.retries && dialect.Retryable(err); attempt++ {
+		err = l.retry(ctx, attempt, err, func() error { return l.insert(ctx, batch) })
+	}
+	if err != nil {
+		return err
+	}
+	if l.retries > 0 {
+		l.pending = append(l.pending, batch...)
+	}
+
+	l.loaded += len(batch)
+	l.uncommitted += len(batch)
+
+	if l.commitEvery > 0 && l.uncommitted >= l.commitEvery {
+		if err := l.Commit(ctx); err != nil {
+			return fmt.Errorf("unable to commit transaction : %w", err)
+		}
+		l.committed += l.uncommitted
+		l.uncommitted = 0
+
+		tx, err := l.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("unable to start transaction : %w", err)
+		}
+		l.tx = tx
+	}
+	return nil
+}
+
+// Commit will commit transaction of the loader, failed commit is retried the same way as failed batch.
+func (l *batchLoader) Commit(ctx context.Context) error {
+	err := l.tx.Commit()
+	for attempt := 1; err != nil && attempt <= l.retries && dialect.Retryable(err); attempt++ {
+		err = l.retry(ctx, attempt, err, func() error { return l.tx.Commit() })
+	}
+	if err != nil {
+		return err
+	}
+	l.pending = l.pending[:0]
+	return nil
+}
+
+// Rollback will roll back transaction of the loader.
+func (l *batchLoader) Rollback() error {
+	return l.tx.Rollback()
+}
+
+// insert will insert events within the current transaction.
+func (l *batchLoader) insert(ctx context.Context, events model.Events) error {
+	q, args := insertQuery(l.d, events)
+	_, err := l.tx.ExecContext(ctx, q, args...)
+	return err
+}
+
+// retry will roll back transaction failed by err, start a new one after backoff of the attempt,
+// insert pending events of the failed transaction again and call fn to finish the failed step.
+func (l *batchLoader) retry(ctx context.Context, attempt int, err error, fn func() error) error {
+	// serialization failure aborts the whole transaction, so it's rolled back, error of already finished one is expected
+	l.tx.Rollback()
+	l.retried++
+
+	backoff := l.backoff << (attempt - 1)
+	if backoff > maxRetryBackoff || backoff <= 0 {
+		backoff = maxRetryBackoff
+	}
+	fmt.Printf("retrying transaction of %d events in %v after %d attempts : %+v\n", len(l.pending), backoff, attempt, err)
+
+	select {
+	case <-time.After(backoff):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to start transaction : %w", err)
+	}
+	l.tx = tx
+
+	for from := 0; from < len(l.pending); from += l.size {
+		to := from + l.size
+		if to > len(l.pending) {
+			to = len(l.pending)
+		}
+		if err := l.insert(ctx, l.pending[from:to]); err != nil {
+			return err
+		}
+	}
+	return fn()
+}
+
+// Counts returns number of loaded events and events skipped because they are already stored.
+func (l *batchLoader) Counts() (int, int) {
+	return l.loaded, l.existing
+}
+
+// Committed returns number of loaded events which are already committed before the last transaction.
+func (l *batchLoader) Committed() int {
+	return l.committed
+}
+
+// Retried returns number of transactions retried after serialization failure or deadlock.
+func (l *batchLoader) Retried() int {
+	return l.retried
+}
+
+// skipExisting returns events of the batch which are not stored in database yet.
+func (l *batchLoader) skipExisting(ctx context.Context, batch model.Events) (model.Events, error) {
+	refs := make([]string, 0, len(batch))
+	for _, e := range batch {
+		refs = append(refs, e.EventRef)
+	}
+
+	q, args := l.d.ExistingRefsQuery(refs)
+	rows, err := l.tx.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query existing events : %w", err)
+	}
+	defer rows.Close()
+
+	existing := map[string]struct{}{}
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, fmt.Errorf("unable to read existing event ref : %w", err)
+		}
+		existing[ref] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to query existing events : %w", err)
+	}
+
+	if len(existing) == 0 {
+		return batch, nil
+	}
+
+	missing := make(model.Events, 0, len(batch)-len(existing))
+	for _, e := range batch {
+		if _, ok := existing[e.EventRef]; !ok {
+			missing = append(missing, e)
+		}
+	}
+	l.existing += len(batch) - len(missing)
+	return missing, nil
+}
+
+// insertQuery builds multi-row insert statement of provided events and its arguments.
+func insertQuery(d dialect.Dialect, events model.Events) (string, []interface{}) {
+	var q strings.Builder
+	columns, perEvent := eventColumns, argsPerEvent
+	if *durationMillis {
+		columns, perEvent = columns+", "+millisColumn, perEvent+1
+	}
+	q.WriteString("insert into event(" + columns + ")\nvalues ")
+
+	args := make([]interface{}, 0, len(events)*perEvent)
+	for i, event := range events {
+		if i > 0 {
+			q.WriteString(",\n       ")
+		}
+
+		// we have to format query to use function for converting time.
+		// date conversion goes through epoch and server time zone, so local time of the event
+		// is kept only if full timestamp is requested
+		date := d.UnixToDate(event.EventDate.Unix())
+		if *keepTime {
+			date = d.Timestamp(event.EventDate)
+		}
+
+		n := i * perEvent
+		fmt.Fprintf(&q, "(%s, %s, %s)", dialect.Placeholders(d, n+1, n+3), date, dialect.Placeholders(d, n+4, n+perEvent))
+
+		args = append(args,
+			event.EventSource,
+			event.EventRef,
+			event.EventType,
+			event.CallingNumber,
+			event.CalledNumber,
+			event.Location,
+			event.DurationSeconds,
+		)
+		for _, attr := range event.Attributes() {
+			args = append(args, attrValue(attr))
+		}
+		if *durationMillis {
+			args = append(args, event.DurationMillis)
+		}
+	}
+	return q.String(), args
+}
+
+// attrValue returns insert argument of attribute, empty attributes are stored as NULL if requested.
+func attrValue(attr string) interface{} {
+	if attr == "" && *emptyAsNull {
+		return nil
+	}
+	return attr
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"github.com/lib/pq"
+)
+
+// insert matches insert statement of events.
+const insert = "insert into event"
+
+// newMockLoader creates postgres loader of single event batches within transaction of mocked database.
+func newMockLoader(t *testing.T) (*batchLoader, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create mock : %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mock.ExpectBegin()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("unable to start transaction : %v", err)
+	}
+
+	l := newBatchLoader(dialect.Postgres{}, tx, 1, false)
+	l.Retry(db, 1, time.Millisecond)
+	return l, mock
+}
+
+func TestBatchLoaderRetriesSerializationFailure(t *testing.T) {
+	l, mock := newMockLoader(t)
+	ctx := context.Background()
+
+	// the second batch fails once, so the whole transaction is replayed by a new one
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WillReturnError(&pq.Error{Code: "40001"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	for _, ref := range []string{"ref-1", "ref-2"} {
+		if err := l.Add(ctx, generator.NewEvent(generator.WithRef(ref))); err != nil {
+			t.Fatalf("unable to add event : %v", err)
+		}
+	}
+	if err := l.Commit(ctx); err != nil {
+		t.Fatalf("unable to commit : %v", err)
+	}
+
+	if loaded, _ := l.Counts(); loaded != 2 {
+		t.Errorf("expected 2 loaded events, got %d", loaded)
+	}
+	if l.Retried() != 1 {
+		t.Errorf("expected 1 retried transaction, got %d", l.Retried())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchLoaderRetriesDeadlockOnCommit(t *testing.T) {
+	l, mock := newMockLoader(t)
+	ctx := context.Background()
+
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit().WillReturnError(&pq.Error{Code: "40P01"})
+	mock.ExpectBegin()
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := l.Add(ctx, generator.NewEvent()); err != nil {
+		t.Fatalf("unable to add event : %v", err)
+	}
+	if err := l.Commit(ctx); err != nil {
+		t.Fatalf("unable to commit : %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchLoaderAbortsOnNonRetryableError(t *testing.T) {
+	l, mock := newMockLoader(t)
+	ctx := context.Background()
+
+	unique := &pq.Error{Code: "23505"}
+	mock.ExpectExec(insert).WillReturnError(unique)
+	mock.ExpectRollback()
+
+	err := l.Add(ctx, generator.NewEvent())
+	if !errors.Is(err, unique) {
+		t.Fatalf("expected unique violation, got %v", err)
+	}
+	l.Rollback()
+
+	if l.Retried() != 0 {
+		t.Errorf("expected no retries, got %d", l.Retried())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchLoaderGivesUpAfterRetries(t *testing.T) {
+	l, mock := newMockLoader(t)
+	ctx := context.Background()
+
+	mock.ExpectExec(insert).WillReturnError(&pq.Error{Code: "40001"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(insert).WillReturnError(&pq.Error{Code: "40001"})
+
+	err := l.Add(ctx, &model.Event{EventRef: "ref"})
+	if !dialect.Retryable(err) {
+		t.Fatalf("expected serialization failure once retries are exhausted, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// newSQLiteDB creates in-memory SQLite database with event table.
+func newSQLiteDB(t testing.TB) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("unable to open database : %v", err)
+	}
+	// every connection of in-memory database is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if err := createSchema(context.Background(), dialect.SQLite{}, db); err != nil {
+		t.Fatalf("unable to create schema : %v", err)
+	}
+	return db
+}
+
+// loadEvents loads events into the database within a single transaction.
+func loadEvents(t *testing.T, db *sql.DB, events ...*model.Event) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("unable to start transaction : %v", err)
+	}
+
+	l := newBatchLoader(dialect.SQLite{}, tx, 10, false)
+	for _, e := range events {
+		if err := l.Add(ctx, e); err != nil {
+			t.Fatalf("unable to add event : %v", err)
+		}
+	}
+	if err := l.Flush(ctx); err != nil {
+		t.Fatalf("unable to flush events : %v", err)
+	}
+	if err := l.Commit(ctx); err != nil {
+		t.Fatalf("unable to commit : %v", err)
+	}
+}
+
+// loadedDate reads back event date of the event with provided ref.
+func loadedDate(t *testing.T, db *sql.DB, ref string) time.Time {
+	t.Helper()
+	var value interface{}
+	if err := db.QueryRow("select event_date from event where event_ref = ?", ref).Scan(&value); err != nil {
+		t.Fatalf("unable to read event : %v", err)
+	}
+	date, err := dialect.ParseTimestamp(value, time.UTC)
+	if err != nil {
+		t.Fatalf("unable to parse event date : %v", err)
+	}
+	return date
+}
+
+func TestLoadKeepsTimeOfDay(t *testing.T) {
+	db := newSQLiteDB(t)
+	date := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	loadEvents(t, db, generator.NewEvent(generator.WithRef("ref-1"), generator.WithDate(date)))
+
+	if loaded := loadedDate(t, db, "ref-1"); !loaded.Equal(date) {
+		t.Errorf("expected event date %v, got %v", date, loaded)
+	}
+}
+
+func TestLoadWithoutKeepTimeStoresDate(t *testing.T) {
+	defer func(keep bool) { *keepTime = keep }(*keepTime)
+	*keepTime = false
+
+	db := newSQLiteDB(t)
+	date := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	loadEvents(t, db, generator.NewEvent(generator.WithRef("ref-1"), generator.WithDate(date)))
+
+	midnight := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+	if loaded := loadedDate(t, db, "ref-1"); !loaded.Equal(midnight) {
+		t.Errorf("expected event date %v, got %v", midnight, loaded)
+	}
+}
+
+func TestCommitEveryKeepsCommittedEventsOnFailure(t *testing.T) {
+	db := newSQLiteDB(t)
+	ctx := context.Background()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("unable to start transaction : %v", err)
+	}
+
+	l := newBatchLoader(dialect.SQLite{}, tx, 1, false)
+	l.CommitEvery(db, 2)
+	for _, ref := range []string{"ref-1", "ref-2", "ref-3", "ref-4", "ref-5"} {
+		if err := l.Add(ctx, generator.NewEvent(generator.WithRef(ref))); err != nil {
+			t.Fatalf("unable to add event : %v", err)
+		}
+	}
+
+	// the load fails within the third transaction
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Add(cancelled, generator.NewEvent(generator.WithRef("ref-6"))); err == nil {
+		t.Fatal("expected cancelled insert to fail")
+	}
+	if err := l.Rollback(); err != nil {
+		t.Fatalf("unable to roll back : %v", err)
+	}
+
+	if committed := l.Committed(); committed != 4 {
+		t.Errorf("expected 4 committed events, got %d", committed)
+	}
+	if count := countEvents(t, db); count != 4 {
+		t.Errorf("expected 4 stored events, got %d", count)
+	}
+}
+
+func TestLoadDurationMillis(t *testing.T) {
+	defer func(millis bool) { *durationMillis = millis }(*durationMillis)
+	*durationMillis = true
+
+	db := newSQLiteDB(t)
+	// column is added once, existing one is kept
+	for i := 0; i < 2; i++ {
+		if err := addMillisColumn(context.Background(), db); err != nil {
+			t.Fatalf("unable to add millis column : %v", err)
+		}
+	}
+
+	e := generator.NewEvent(generator.WithRef("ref-1"))
+	e.DurationSeconds, e.DurationMillis = 12, 12345
+	loadEvents(t, db, e)
+
+	var seconds, millis int
+	if err := db.QueryRow("select duration_seconds, duration_millis from event where event_ref = ?", "ref-1").Scan(&seconds, &millis); err != nil {
+		t.Fatalf("unable to read event : %v", err)
+	}
+	if seconds != 12 || millis != 12345 {
+		t.Errorf("expected 12s and 12345ms, got %ds and %dms", seconds, millis)
+	}
+}
+
+func TestLoadEmptyAttributesAsNull(t *testing.T) {
+	defer func(null bool) { *emptyAsNull = null }(*emptyAsNull)
+
+	for _, null := range []bool{false, true} {
+		*emptyAsNull = null
+		db := newSQLiteDB(t)
+		loadEvents(t, db, generator.NewEvent(generator.WithRef("ref-1"), generator.WithAttributes([8]string{"a", "", "c"})))
+
+		var attr1, attr2, attr8 sql.NullString
+		if err := db.QueryRow("select attr_1, attr_2, attr_8 from event where event_ref = ?", "ref-1").Scan(&attr1, &attr2, &attr8); err != nil {
+			t.Fatalf("unable to read event : %v", err)
+		}
+		if !attr1.Valid || attr1.String != "a" {
+			t.Errorf("empty as null %t : expected attr_1 'a', got %v", null, attr1)
+		}
+		for _, attr := range []sql.NullString{attr2, attr8} {
+			if attr.Valid == null || attr.String != "" {
+				t.Errorf("empty as null %t : expected NULL %t of empty attribute, got %v", null, null, attr)
+			}
+		}
+	}
+}
+
+func TestLoadKeepsLocalTimeOfZone(t *testing.T) {
+	defer func(keep bool) { *keepTime = keep }(*keepTime)
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Fatalf("unable to load time zone : %v", err)
+	}
+	// half past midnight in Kyiv is the previous day in UTC
+	date := time.Date(2022, 3, 15, 0, 30, 0, 0, kyiv)
+
+	tests := []struct {
+		keepTime bool
+		expected time.Time
+	}{
+		{keepTime: true, expected: time.Date(2022, 3, 15, 0, 30, 0, 0, time.UTC)},
+		// date is converted through epoch, so it's the date of server time zone
+		{keepTime: false, expected: time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		*keepTime = test.keepTime
+		db := newSQLiteDB(t)
+		loadEvents(t, db, generator.NewEvent(generator.WithRef("ref-1"), generator.WithDate(date)))
+
+		if loaded := loadedDate(t, db, "ref-1"); !loaded.Equal(test.expected) {
+			t.Errorf("keep time %t : expected event date %v, got %v", test.keepTime, test.expected, loaded)
+		}
+	}
+}
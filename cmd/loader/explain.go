@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"strings"
+)
+
+// errBatchCollected stops reading input once the first batch is collected.
+var errBatchCollected = errors.New("batch collected")
+
+// explainLoad will print plans of the queries used to load the first batch of input files without executing them.
+func explainLoad(ctx context.Context, db *sql.DB, d dialect.Dialect, inputFiles []string) error {
+	batch := make(model.Events, 0, *batchSize)
+	for _, inputFile := range inputFiles {
+		_, err := loadFile(inputFile, func(e *model.Event) error {
+			batch = append(batch, e)
+			if len(batch) >= *batchSize {
+				return errBatchCollected
+			}
+			return nil
+		})
+		if errors.Is(err, errBatchCollected) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%s : %w", inputFile, err)
+		}
+	}
+	if len(batch) == 0 {
+		fmt.Println("nothing to explain, input is empty")
+		return nil
+	}
+
+	if *resume {
+		refs := make([]string, 0, len(batch))
+		for _, e := range batch {
+			refs = append(refs, e.EventRef)
+		}
+		q, args := d.ExistingRefsQuery(refs)
+		fmt.Printf("existing events query of %d events :\n", len(batch))
+		explainQuery(ctx, db, d, q, args)
+	}
+
+	q, args := insertQuery(d, batch)
+	fmt.Printf("insert of %d events :\n", len(batch))
+	explainQuery(ctx, db, d, q, args)
+	return nil
+}
+
+// explainQuery will print plan of the query, failure is reported as not every database is able to explain every query.
+func explainQuery(ctx context.Context, db *sql.DB, d dialect.Dialect, q string, args []interface{}) {
+	rows, err := db.QueryContext(ctx, d.Explain(q), args...)
+	if err != nil {
+		fmt.Printf("unable to explain query on %s : %+v\n", d.Name(), err)
+		return
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		fmt.Printf("unable to read plan : %+v\n", err)
+		return
+	}
+	fmt.Println(strings.Join(columns, " | "))
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			fmt.Printf("unable to read plan : %+v\n", err)
+			return
+		}
+
+		line := make([]string, 0, len(values))
+		for _, v := range values {
+			line = append(line, v.String)
+		}
+		fmt.Println(strings.Join(line, " | "))
+	}
+	if err := rows.Err(); err != nil {
+		fmt.Printf("unable to read plan : %+v\n", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"io"
+	"os"
+	"strings"
+)
+
+// supported input formats.
+const (
+	formatAuto   = generator.FormatAuto
+	formatJSON   = generator.FormatJSON
+	formatNDJSON = generator.FormatNDJSON
+)
+
+// detectFormat returns input format of provided file, format is detected by extension if it's not set explicitly.
+// Standard input is expected to be ndjson.
+func detectFormat(format, inputFile string) (string, error) {
+	return generator.DetectFormat(format, inputFile, formatJSON, formatNDJSON)
+}
+
+// hasEvents reports whether input file has at least one event record, so empty inputs are detected
+// without opening transaction. Standard input can't be peeked, so it's expected to have events.
+func hasEvents(inputFile string) (bool, error) {
+	if inputFile == "-" {
+		return true, nil
+	}
+
+	inputFormat, err := detectFormat(*format, inputFile)
+	if err != nil {
+		return false, fmt.Errorf("unable to detect input format : %w", err)
+	}
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return false, fmt.Errorf("unable to open input file : %w", err)
+	}
+	defer file.Close()
+
+	if inputFormat == formatNDJSON {
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), generator.MaxLineSize)
+		for scanner.Scan() {
+			if len(strings.TrimSpace(scanner.Text())) > 0 {
+				return true, nil
+			}
+		}
+		return false, scanner.Err()
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(file))
+	token, err := dec.Token()
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to read array start : %v : %w", err, generator.ErrBadInput)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return false, fmt.Errorf("json array expected, got %v : %w", token, generator.ErrBadInput)
+	}
+	return dec.More(), nil
+}
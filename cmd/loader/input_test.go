@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestHasEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		expected bool
+		// badInput is set if input is expected to be rejected as malformed.
+		badInput bool
+	}{
+		{name: "empty json file", filename: "events.json"},
+		{name: "whitespace json file", filename: "events.json", content: " \n\t\n"},
+		{name: "empty array", filename: "events.json", content: "[]"},
+		{name: "empty array with whitespace", filename: "events.json", content: "[ \n ]\n"},
+		{name: "array with event", filename: "events.json", content: `[{"event_ref":"ref-1"}]`, expected: true},
+		{name: "object instead of array", filename: "events.json", content: `{"event_ref":"ref-1"}`, badInput: true},
+		{name: "malformed json", filename: "events.json", content: "}{", badInput: true},
+		{name: "empty ndjson file", filename: "events.ndjson"},
+		{name: "blank ndjson lines", filename: "events.ndjson", content: "\n  \n\t\n"},
+		{name: "ndjson with event", filename: "events.ndjson", content: "\n{\"event_ref\":\"ref-1\"}\n", expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), test.filename)
+			if err := os.WriteFile(filename, []byte(test.content), 0644); err != nil {
+				t.Fatalf("unable to write file : %v", err)
+			}
+
+			found, err := hasEvents(filename)
+			if test.badInput {
+				if !errors.Is(err, generator.ErrBadInput) {
+					t.Fatalf("expected bad input error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to check events : %v", err)
+			}
+			if found != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, found)
+			}
+		})
+	}
+}
+
+func TestHasEventsOfMissingFile(t *testing.T) {
+	if _, err := hasEvents(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
+
+func TestHasEventsOfStandardInput(t *testing.T) {
+	found, err := hasEvents("-")
+	if err != nil {
+		t.Fatalf("unable to check events : %v", err)
+	}
+	if !found {
+		t.Error("expected standard input to have events")
+	}
+}
+
+func TestLoadFileFromStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unable to create pipe : %v", err)
+	}
+	defer func(stdin *os.File) { os.Stdin = stdin }(os.Stdin)
+	os.Stdin = r
+
+	input := ndjsonEvents(t, 100)
+	go func() {
+		w.Write(input)
+		w.Close()
+	}()
+
+	loaded := 0
+	skipped, err := loadFile("-", func(e *model.Event) error {
+		loaded++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to load standard input : %v", err)
+	}
+	if skipped != 0 || loaded != 100 {
+		t.Errorf("expected 100 loaded events and no skipped lines, got %d and %d", loaded, skipped)
+	}
+}
+
+func TestReadEventsStreamsLargeArray(t *testing.T) {
+	const n = 200000
+	events := make(model.Events, 100)
+	for i := range events {
+		events[i] = generator.RandomEvent(generator.Options{})
+	}
+
+	// array is written to pipe while it's read, so it's never kept in memory as a whole
+	r, w := io.Pipe()
+	var written atomic.Bool
+	go func() {
+		sink, err := generator.NewWriterSink(w, generator.FormatJSON, false)
+		if err != nil {
+			w.CloseWithError(err)
+			return
+		}
+		for i := 0; i < n; i++ {
+			if err := sink.Write(events[i%len(events)]); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+		}
+		err = sink.Close()
+		written.Store(true)
+		w.CloseWithError(err)
+	}()
+
+	read := 0
+	skipped, err := generator.StreamEvents(r, formatJSON, func(e *model.Event) error {
+		if read == 0 && written.Load() {
+			return errors.New("the first event is decoded after the whole array is written")
+		}
+		if expected := events[read%len(events)]; e.EventRef != expected.EventRef {
+			return fmt.Errorf("event %d : expected ref %s, got %s", read, expected.EventRef, e.EventRef)
+		}
+		read++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read events : %v", err)
+	}
+	if skipped != 0 || read != n {
+		t.Errorf("expected %d read events and no skipped ones, got %d and %d", n, read, skipped)
+	}
+}
+
+func TestLoadNDJSONAcceptsBothCases(t *testing.T) {
+	input := `{"event_ref":"ref-1","event_type":2,"location":"KYIV"}
+{"eventRef":"ref-2","eventType":5,"location":"LVIV"}
+`
+	var events model.Events
+	skipped, err := generator.StreamNDJSON(strings.NewReader(input), func(e *model.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil || skipped != 0 {
+		t.Fatalf("unable to read events : %v, skipped %d", err, skipped)
+	}
+	if len(events) != 2 || events[0].EventRef != "ref-1" || events[1].EventRef != "ref-2" || events[1].EventType != model.EventTypeDataSession {
+		t.Errorf("expected events of both cases, got %+v", events)
+	}
+}
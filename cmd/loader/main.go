@@ -1,16 +1,57 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/metrics"
 	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
 	"github.com/xo/dburl"
-	"io/ioutil"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
+	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// exitBadInput is exit code of malformed input, so scripts could tell it from other failures.
+const exitBadInput = 3
+
+// metricsBatchSize is number of events reported to metrics at once.
+const metricsBatchSize = 1000
+
+var (
+	initSchema      = flag.Bool("init-schema", false, "create event table if it does not exist before loading")
+	metricsAddr     = flag.String("metrics-addr", "", "address to expose Prometheus metrics on, e.g. :9090, disabled if not set")
+	format          = flag.String("format", formatAuto, "input format: json (single array), ndjson (event per line) or auto to detect by file extension")
+	batchSize       = flag.Int("batch-size", 1000, "number of events inserted with a single statement")
+	keepTime        = flag.Bool("keep-time", true, "keep time of day and local time of event date zone, -keep-time=false stores only the date as before")
+	resume          = flag.Bool("resume", false, "skip events which are already loaded, so interrupted load could be continued")
+	emptyAsNull     = flag.Bool("empty-as-null", false, "store empty attributes as NULL")
+	explain         = flag.Bool("explain", false, "print plans of the queries loading the first batch without executing them and exit")
+	verifyManifest  = flag.Bool("verify-manifest", false, "verify input files against their .sha256 manifests before loading and number of events after reading")
+	connTimeout     = flag.Duration("conn-timeout", 30*time.Second, "time limit of connecting to database")
+	loadTimeout     = flag.Duration("timeout", 0, "time limit of the whole load, transaction is rolled back once it is exceeded, not limited if not set")
+	maxOpenConns    = flag.Int("max-open-conns", 4, "max number of open database connections, 0 - unlimited")
+	maxIdleConns    = flag.Int("max-idle-conns", 2, "max number of idle database connections kept in the pool")
+	connMaxLifetime = flag.Duration("conn-max-lifetime", 30*time.Minute, "max time a database connection is reused, 0 - forever")
+	workers         = flag.Int("workers", 0, "number of goroutines inserting events decoded by a separate goroutine, every worker loads in its own transaction, 0 - decode and insert sequentially in a single transaction")
+	watermarkFile   = flag.String("watermark", "", "file storing date and refs of the latest loaded events, only newer events and not loaded events of the same date are loaded, it is moved after successful load")
+	durationMillis  = flag.Bool("duration-millis", false, "load duration_millis of events generated with -duration-unit ms, column is added to event table by -init-schema")
+	commitEvery     = flag.Int("commit-every", 0, "commit transaction and start a new one every N loaded events (per worker with -workers), committed events are kept if load fails later, so load could be partial; 0 - load all events in a single transaction")
+	maxAttrLen      = flag.Int("max-attr-len", 0, "max length of event attributes in characters, longer ones are handled by -long-attrs, not limited if not set")
+	longAttrs       = flag.String("long-attrs", longAttrsTruncate, "handling of attributes longer than -max-attr-len: truncate them or reject skipping the event")
+	retries         = flag.Int("retries", 0, "number of retries of transaction failed by postgres serialization failure or deadlock (SQLSTATE 40001, 40P01), postgres only and requires -commit-every, as events of retried transaction are inserted again")
+	retryBackoff    = flag.Duration("retry-backoff", 100*time.Millisecond, "delay before the first retry of a transaction, it doubles with every retry up to 5s")
 )
 
 // "postgresql://nrm:nrm@pg:5432/nrm?sslmode=disable"
@@ -18,8 +59,14 @@ import (
 // Loader will read generated dump and load it in provided DB.
 //
 // arg 1 is DB URL for database to load data
-// atg 2 is path to file to load
+// atg 2.. are paths to files to load, e.g. rolled events.0001.ndjson events.0002.ndjson, all of them are loaded in a single transaction,
+// - reads events from standard input
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <database url> <input file>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
 
 	// log time duration on application shutdown
 	start := time.Now()
@@ -29,94 +76,301 @@ func main() {
 	}()
 
 	// validate inputs firstly
-	if len(os.Args) != 3 {
-		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", len(os.Args)-1))
+	if flag.NArg() < 2 {
+		panic(fmt.Errorf("invalid number of arguments, at least 2 expected, got %d", flag.NArg()))
+	}
+
+	inputFiles := flag.Args()[1:]
+
+	var limiter *attrLimiter
+	if *maxAttrLen != 0 {
+		var err error
+		limiter, err = newAttrLimiter(*maxAttrLen, *longAttrs)
+		if err != nil {
+			panic(fmt.Errorf("invalid attribute length limit : %+v", err))
+		}
 	}
 
-	inputFile := os.Args[2]
+	fmt.Printf("input files: %s\n", strings.Join(inputFiles, ", "))
 
-	fmt.Printf("input file: %s\n", inputFile)
+	// empty inputs are reported without touching database
+	empty := true
+	for _, inputFile := range inputFiles {
+		ok, err := hasEvents(inputFile)
+		if err != nil {
+			exitOnBadInput(fmt.Errorf("%s : %w", inputFile, err))
+			panic(fmt.Errorf("unable to read input file : %+v", err))
+		}
+		if ok {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		fmt.Println("no events to load")
+		return
+	}
 
-	dbUrl := os.Args[1]
+	dbUrl := flag.Arg(0)
 	url, err := dburl.Parse(dbUrl)
 	if err != nil {
-		panic(fmt.Errorf("unable to parse database URL '%s' : %+v", url, err))
+		panic(fmt.Errorf("unable to parse database URL '%s' : %+v", dbUrl, err))
 	}
 
-	eventRaw, err := ioutil.ReadFile(inputFile)
+	// hung database must not hang the loader, connection is given limited time
+	connCtx, cancel := context.WithTimeout(context.Background(), *connTimeout)
+	db, d, err := dialect.Open(connCtx, url)
+	cancel()
 	if err != nil {
-		panic(fmt.Errorf("unable to read input file : %+v", err))
+		panic(fmt.Errorf("unable to connect to database : %+v", err))
 	}
+	defer db.Close()
 
-	var events []*model.Event
+	db.SetMaxOpenConns(*maxOpenConns)
+	db.SetMaxIdleConns(*maxIdleConns)
+	db.SetConnMaxLifetime(*connMaxLifetime)
 
-	err = json.Unmarshal(eventRaw, &events)
-	if err != nil {
-		panic(fmt.Errorf("unable to unmarshall event file content : %+v", err))
+	var jobMetrics *metrics.Metrics
+	if *metricsAddr != "" {
+		jobMetrics = metrics.New("loader")
+		if err := jobMetrics.Serve(*metricsAddr); err != nil {
+			panic(fmt.Errorf("unable to start metrics server : %+v", err))
+		}
+		defer jobMetrics.Shutdown()
 	}
+	batcher := metrics.NewBatcher(jobMetrics, metricsBatchSize)
+	// database is connected, so loader is ready
+	jobMetrics.SetReady(true)
 
-	fmt.Printf("Total events to load : %d\n", len(events))
+	// stop loading gracefully on interruption
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *loadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *loadTimeout)
+		defer cancel()
+	}
 
-	db, err := sql.Open("postgres", url.DSN)
-	if err != nil {
-		panic(fmt.Errorf("unable to connecto to database : %+v", err))
+	if *initSchema {
+		err = createSchema(ctx, d, db)
+		if err != nil {
+			panic(fmt.Errorf("unable to create schema : %+v", err))
+		}
+		if *durationMillis {
+			if err := addMillisColumn(ctx, db); err != nil {
+				panic(fmt.Errorf("unable to add %s column : %+v", millisColumn, err))
+			}
+		}
+	}
+
+	if *explain {
+		if err := explainLoad(ctx, db, d, inputFiles); err != nil {
+			panic(fmt.Errorf("unable to explain loading : %+v", err))
+		}
+		return
+	}
+
+	if *retries < 0 || *retryBackoff <= 0 {
+		panic(fmt.Errorf("retries must not be negative and retry backoff must be positive, got %d and %v", *retries, *retryBackoff))
+	}
+	// only postgres errors are recognized as retryable and retried transaction is kept in memory
+	if *retries > 0 && d.Name() != "postgres" {
+		panic(fmt.Errorf("retries are supported for postgres only, got %s", d.Name()))
+	}
+	if *retries > 0 && *commitEvery == 0 {
+		panic(fmt.Errorf("retries require -commit-every, so events of retried transaction are bounded"))
 	}
 
-	tx, err := db.Begin()
+	// sqlite locks the whole database for writing transaction
+	if *workers > 1 && d.Name() == "sqlite3" {
+		panic(fmt.Errorf("sqlite supports a single writing transaction, at most 1 worker expected, got %d", *workers))
+	}
+
+	newLoader := func(tx *sql.Tx) *batchLoader {
+		bl := newBatchLoader(d, tx, *batchSize, *resume)
+		if *commitEvery > 0 {
+			bl.CommitEvery(db, *commitEvery)
+		}
+		if *retries > 0 {
+			bl.Retry(db, *retries, *retryBackoff)
+		}
+		return bl
+	}
+
+	var l eventLoader
+	if *workers > 0 {
+		l, err = newPipelineLoader(ctx, db, *workers, *batchSize, newLoader)
+	} else {
+		var tx *sql.Tx
+		tx, err = db.BeginTx(ctx, nil)
+		l = newLoader(tx)
+	}
 	if err != nil {
 		panic(fmt.Errorf("unable to start transaction : %+v", err))
 	}
-	defer db.Close()
 
-	for _, e := range events {
-		err = load(tx, e)
+	// files are verified before loading, so corrupted file is not loaded even partially
+	expected := 0
+	if *verifyManifest {
+		for _, inputFile := range inputFiles {
+			m, err := generator.VerifyManifest(inputFile)
+			if err != nil {
+				panic(fmt.Errorf("manifest verification of %s failed : %+v", inputFile, err))
+			}
+			expected += m.Count
+		}
+		fmt.Printf("manifests verified, %d events expected\n", expected)
+	}
+
+	// events older than watermark or of its date loaded by previous runs are skipped
+	var loadedMark, latest *watermark
+	if *watermarkFile != "" {
+		loadedMark, err = readWatermark(*watermarkFile)
+		if err != nil {
+			panic(fmt.Errorf("unable to read watermark : %+v", err))
+		}
+		latest = loadedMark.Clone()
+		if !loadedMark.Date.IsZero() {
+			fmt.Printf("loading events newer than %s or not loaded yet events of it\n", loadedMark.Date.Format(time.RFC3339Nano))
+		}
+	}
+
+	skipped := 0
+	read := 0
+	old := 0
+	for _, inputFile := range inputFiles {
+		var n int
+		n, err = loadFile(inputFile, func(e *model.Event) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			batcher.Inc()
+			read++
+			if limiter != nil && !limiter.Accept(e) {
+				return nil
+			}
+			if loadedMark != nil {
+				if loadedMark.Loaded(e) {
+					old++
+					return nil
+				}
+				latest.Advance(e)
+			}
+			return l.Add(ctx, e)
+		})
+		skipped += n
 		if err != nil {
-			tx.Rollback()
-			panic(fmt.Errorf("unable to load event : %+v", err))
+			err = fmt.Errorf("%s : %w", inputFile, err)
+			break
 		}
 	}
+	if err == nil {
+		err = l.Flush(ctx)
+	}
+	batcher.Flush()
 
-	fmt.Printf("sucessfully loaded %d events\n", len(events))
+	if err != nil {
+		l.Rollback()
+		loaded, _ := l.Counts()
+		// intermediately committed events are kept, so load could be continued with -resume
+		if committed := l.Committed(); committed > 0 {
+			fmt.Printf("%d events are committed and kept in database\n", committed)
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			panic(fmt.Errorf("loading timed out after %v and %d events, transaction rolled back", *loadTimeout, loaded))
+		}
+		if ctx.Err() != nil {
+			fmt.Printf("loading cancelled after %d events, transaction rolled back\n", loaded)
+			return
+		}
+		exitOnBadInput(err)
+		panic(fmt.Errorf("unable to load events : %+v", err))
+	}
 
-	tx.Commit()
+	if *verifyManifest && read+skipped != expected {
+		l.Rollback()
+		panic(fmt.Errorf("manifest verification failed, %d events expected, %d read", expected, read+skipped))
+	}
 
+	if skipped > 0 {
+		fmt.Printf("skipped %d malformed events\n", skipped)
+	}
+	if *watermarkFile != "" {
+		fmt.Printf("skipped %d events already loaded according to watermark\n", old)
+	}
+	if limiter != nil && limiter.reject {
+		fmt.Printf("skipped %d events with attributes longer than %d characters\n", limiter.rejected, limiter.max)
+	}
+	if limiter != nil && !limiter.reject {
+		fmt.Printf("truncated attributes of %d events to %d characters\n", limiter.truncated, limiter.max)
+	}
+	loaded, existing := l.Counts()
+	if *resume {
+		fmt.Printf("skipped %d already loaded events\n", existing)
+	}
+	if retried := l.Retried(); retried > 0 {
+		fmt.Printf("retried %d transactions after serialization failures or deadlocks\n", retried)
+	}
+
+	if err := l.Commit(ctx); err != nil {
+		panic(fmt.Errorf("unable to commit loaded events : %+v", err))
+	}
+	// watermark is moved only once events are committed, so failed load is retried
+	if latest != nil && latest.Moved() {
+		if err := writeWatermark(*watermarkFile, latest); err != nil {
+			panic(fmt.Errorf("events are loaded, but watermark is not updated : %+v", err))
+		}
+	}
+	fmt.Printf("sucessfully loaded %d events\n", loaded)
+}
+
+// exitOnBadInput will exit with exitBadInput code if err is caused by malformed input.
+func exitOnBadInput(err error) {
+	if errors.Is(err, generator.ErrBadInput) {
+		fmt.Fprintf(os.Stderr, "malformed input : %+v\n", err)
+		os.Exit(exitBadInput)
+	}
 }
 
-// load will save event to database.
-func load(tx *sql.Tx, event *model.Event) error {
-
-	q := `
-insert into event(event_source, event_ref, event_type, event_date, calling_number, called_number, location,
-                  duration_seconds, attr_1, attr_2, attr_3, attr_4, attr_5, attr_6, attr_7, attr_8)
-values ($1, $2, $3, %s, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-`
-
-	// we have to format query to use function for converting time
-	q = fmt.Sprintf(q, timeToTimestampNoTz(&event.EventDate))
-
-	_, err := tx.Exec(q,
-		event.EventSource,
-		event.EventRef,
-		event.EventType,
-		event.CallingNumber,
-		event.CalledNumber,
-		event.Location,
-		event.DurationSeconds,
-		event.Attr1,
-		event.Attr2,
-		event.Attr3,
-		event.Attr4,
-		event.Attr5,
-		event.Attr6,
-		event.Attr7,
-		event.Attr8,
-	)
+// loadFile will call fn for every event of provided file, file format is detected according to format flag.
+// Returns number of skipped malformed records.
+func loadFile(inputFile string, fn func(e *model.Event) error) (int, error) {
+	inputFormat, err := detectFormat(*format, inputFile)
+	if err != nil {
+		return 0, fmt.Errorf("unable to detect input format : %w", err)
+	}
 
+	// - means events are piped to standard input
+	if inputFile == "-" {
+		return generator.StreamEvents(bufio.NewReader(os.Stdin), inputFormat, fn)
+	}
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return 0, fmt.Errorf("unable to open input file : %w", err)
+	}
+	defer file.Close()
+
+	return generator.StreamEvents(bufio.NewReader(file), inputFormat, fn)
+}
+
+// addMillisColumn will add optional column of durations in milliseconds if event table doesn't have it yet.
+// Column is probed with a query, as not all the dialects support adding column if it does not exist.
+func addMillisColumn(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, "select "+millisColumn+" from event where 1 = 0")
+	if err == nil {
+		return rows.Close()
+	}
+	_, err = db.ExecContext(ctx, "alter table event add column "+millisColumn+" BIGINT")
 	return err
 }
 
-// timeToTimestampNoTz will format go time to timestamp - thus will allow us to use epoch time
-// and don't rely on client and server timezones.
-func timeToTimestampNoTz(t *time.Time) string {
-	return fmt.Sprintf("to_timestamp(cast(%d as bigint))::date", t.Unix())
+// createSchema will create event table and its indexes if they don't exist.
+func createSchema(ctx context.Context, d dialect.Dialect, db *sql.DB) error {
+	for _, stmt := range d.Schema() {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"sync"
+)
+
+// eventLoader saves events to database within transaction.
+type eventLoader interface {
+	// Add will save event, events may be buffered until Flush.
+	Add(ctx context.Context, e *model.Event) error
+	// Flush will save buffered events, no events are added afterwards.
+	Flush(ctx context.Context) error
+	// Commit will commit loaded events, loader is not used afterwards.
+	Commit(ctx context.Context) error
+	Rollback() error
+	// Counts returns number of loaded events and events skipped because they are already stored.
+	Counts() (loaded, existing int)
+	// Committed returns number of loaded events kept on rollback, as they are committed by intermediate commits.
+	Committed() int
+	// Retried returns number of transactions retried after serialization failure or deadlock.
+	Retried() int
+}
+
+// pipelineLoader decodes and inserts events concurrently: events are pushed to a bounded channel
+// and inserted by batches by workers, so decoding overlaps with database work.
+// Every worker inserts in its own transaction, transactions are committed once all the events are loaded,
+// so the load is not atomic if one of commits fails.
+type pipelineLoader struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	loaders []*batchLoader
+	// events buffer at most batch of events per worker, so memory is bounded.
+	events chan *model.Event
+	wg     sync.WaitGroup
+	once   sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+// newPipelineLoader creates loader inserting events by provided number of workers,
+// every worker inserts by batches of provided size with loader created by newLoader within its transaction.
+func newPipelineLoader(ctx context.Context, db *sql.DB, workers, size int, newLoader func(tx *sql.Tx) *batchLoader) (*pipelineLoader, error) {
+	// cancellation of the context rolls transactions back, so failed worker stops the rest
+	ctx, cancel := context.WithCancel(ctx)
+	p := &pipelineLoader{ctx: ctx, cancel: cancel, events: make(chan *model.Event, workers*size)}
+
+	for i := 0; i < workers; i++ {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		p.loaders = append(p.loaders, newLoader(tx))
+	}
+
+	p.wg.Add(workers)
+	for _, l := range p.loaders {
+		go p.work(l)
+	}
+	return p, nil
+}
+
+// work will insert events of the channel until it's closed.
+func (p *pipelineLoader) work(l *batchLoader) {
+	defer p.wg.Done()
+	for e := range p.events {
+		if err := l.Add(p.ctx, e); err != nil {
+			p.fail(err)
+			return
+		}
+	}
+	if err := l.Flush(p.ctx); err != nil {
+		p.fail(err)
+	}
+}
+
+// fail records the first error of workers and stops the rest of them.
+func (p *pipelineLoader) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = err
+		p.cancel()
+	}
+}
+
+// error returns the first error of workers.
+func (p *pipelineLoader) error() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Add will push event to workers.
+func (p *pipelineLoader) Add(ctx context.Context, e *model.Event) error {
+	select {
+	case p.events <- e:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.ctx.Done():
+		if err := p.error(); err != nil {
+			return err
+		}
+		return p.ctx.Err()
+	}
+}
+
+// Flush will wait until workers insert all the pushed events.
+func (p *pipelineLoader) Flush(ctx context.Context) error {
+	p.wait()
+	return p.error()
+}
+
+// wait will stop accepting events and wait for workers.
+func (p *pipelineLoader) wait() {
+	p.once.Do(func() { close(p.events) })
+	p.wg.Wait()
+}
+
+// Commit will commit transactions of all the workers.
+func (p *pipelineLoader) Commit(ctx context.Context) error {
+	defer p.cancel()
+	p.wait()
+	for _, l := range p.loaders {
+		if err := l.Commit(p.ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback will roll back transactions of all the workers.
+func (p *pipelineLoader) Rollback() error {
+	defer p.cancel()
+	p.wait()
+	var first error
+	for _, l := range p.loaders {
+		if err := l.Rollback(); err != nil && err != sql.ErrTxDone && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Committed returns number of events committed by intermediate commits of all the workers.
+func (p *pipelineLoader) Committed() int {
+	committed := 0
+	for _, l := range p.loaders {
+		committed += l.committed
+	}
+	return committed
+}
+
+// Retried returns number of transactions retried by all the workers.
+func (p *pipelineLoader) Retried() int {
+	retried := 0
+	for _, l := range p.loaders {
+		retried += l.retried
+	}
+	return retried
+}
+
+// Counts returns number of events loaded and skipped by all the workers.
+func (p *pipelineLoader) Counts() (int, int) {
+	loaded, existing := 0, 0
+	for _, l := range p.loaders {
+		loaded += l.loaded
+		existing += l.existing
+	}
+	return loaded, existing
+}
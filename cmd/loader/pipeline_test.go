@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// benchmarkEvents is number of events loaded by every iteration of load benchmarks.
+const benchmarkEvents = 10000
+
+// ndjsonEvents returns provided number of random events as newline delimited json.
+func ndjsonEvents(tb testing.TB, n int) []byte {
+	tb.Helper()
+	var buf bytes.Buffer
+	sink, err := generator.NewWriterSink(&buf, generator.FormatNDJSON, false)
+	if err != nil {
+		tb.Fatalf("unable to create sink : %v", err)
+	}
+	for i := 0; i < n; i++ {
+		if err := sink.Write(generator.RandomEvent(generator.Options{})); err != nil {
+			tb.Fatalf("unable to write event : %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		tb.Fatalf("unable to close sink : %v", err)
+	}
+	return buf.Bytes()
+}
+
+// load will decode events and load them with provided loader the way main does.
+func load(tb testing.TB, l eventLoader, input []byte) {
+	tb.Helper()
+	ctx := context.Background()
+	_, err := generator.StreamNDJSON(bytes.NewReader(input), func(e *model.Event) error {
+		return l.Add(ctx, e)
+	})
+	if err == nil {
+		err = l.Flush(ctx)
+	}
+	if err != nil {
+		l.Rollback()
+		tb.Fatalf("unable to load events : %v", err)
+	}
+	if err := l.Commit(ctx); err != nil {
+		tb.Fatalf("unable to commit : %v", err)
+	}
+}
+
+// countEvents returns number of events stored in the database.
+func countEvents(tb testing.TB, db *sql.DB) int {
+	tb.Helper()
+	var count int
+	if err := db.QueryRow("select count(*) from event").Scan(&count); err != nil {
+		tb.Fatalf("unable to count events : %v", err)
+	}
+	return count
+}
+
+// newSQLiteLoader creates loader of sqlite batches of provided size.
+func newSQLiteLoader(size int) func(tx *sql.Tx) *batchLoader {
+	return func(tx *sql.Tx) *batchLoader {
+		return newBatchLoader(dialect.SQLite{}, tx, size, false)
+	}
+}
+
+func TestPipelineLoaderLoadsAllEvents(t *testing.T) {
+	db := newSQLiteDB(t)
+	input := ndjsonEvents(t, 1234)
+
+	l, err := newPipelineLoader(context.Background(), db, 1, 100, newSQLiteLoader(100))
+	if err != nil {
+		t.Fatalf("unable to create loader : %v", err)
+	}
+	load(t, l, input)
+
+	if loaded, _ := l.Counts(); loaded != 1234 {
+		t.Errorf("expected 1234 loaded events, got %d", loaded)
+	}
+	if count := countEvents(t, db); count != 1234 {
+		t.Errorf("expected 1234 stored events, got %d", count)
+	}
+}
+
+func BenchmarkLoadSequential(b *testing.B) {
+	input := ndjsonEvents(b, benchmarkEvents)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		db := newSQLiteDB(b)
+		tx, err := db.Begin()
+		if err != nil {
+			b.Fatalf("unable to start transaction : %v", err)
+		}
+		b.StartTimer()
+
+		load(b, newSQLiteLoader(1000)(tx), input)
+	}
+}
+
+func BenchmarkLoadPipelined(b *testing.B) {
+	input := ndjsonEvents(b, benchmarkEvents)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		db := newSQLiteDB(b)
+		b.StartTimer()
+
+		// sqlite supports a single writing transaction, so decoding overlaps with the only worker
+		l, err := newPipelineLoader(context.Background(), db, 1, 1000, newSQLiteLoader(1000))
+		if err != nil {
+			b.Fatalf("unable to create loader : %v", err)
+		}
+		load(b, l, input)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// watermark is date of the latest loaded event and refs of loaded events of that date,
+// so events of the same date arriving with a later file are still loaded.
+type watermark struct {
+	// Date is date of the latest loaded event, zero if nothing is loaded yet.
+	Date time.Time
+	// refs are refs of loaded events dated Date, nil for watermarks stored before refs were recorded,
+	// all the events of Date are considered loaded then.
+	refs map[string]struct{}
+	// moved is set once watermark is changed by loaded events.
+	moved bool
+}
+
+// Loaded reports whether event is loaded according to the watermark.
+func (w *watermark) Loaded(e *model.Event) bool {
+	switch {
+	case e.EventDate.Before(w.Date):
+		return true
+	case e.EventDate.After(w.Date):
+		return false
+	case w.refs == nil:
+		return true
+	}
+	_, ok := w.refs[e.EventRef]
+	return ok
+}
+
+// Advance will move watermark to the loaded event if it's not older than the watermark.
+func (w *watermark) Advance(e *model.Event) {
+	switch {
+	case e.EventDate.After(w.Date):
+		w.Date = e.EventDate
+		w.refs = map[string]struct{}{}
+	case e.EventDate.Before(w.Date), w.refs == nil:
+		return
+	}
+	w.refs[e.EventRef] = struct{}{}
+	w.moved = true
+}
+
+// Moved reports whether watermark is changed by loaded events.
+func (w *watermark) Moved() bool {
+	return w.moved
+}
+
+// Clone returns copy of the watermark, so it could be advanced while the original one filters events.
+func (w *watermark) Clone() *watermark {
+	clone := &watermark{Date: w.Date}
+	if w.refs != nil {
+		clone.refs = make(map[string]struct{}, len(w.refs))
+		for ref := range w.refs {
+			clone.refs[ref] = struct{}{}
+		}
+	}
+	return clone
+}
+
+// readWatermark returns watermark stored in the file, empty watermark if file does not exist yet.
+// The first line of the file is the date, refs of loaded events of the date follow it one per line.
+func readWatermark(filename string) (*watermark, error) {
+	content, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return &watermark{refs: map[string]struct{}{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read watermark : %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	date, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(lines[0]))
+	if err != nil {
+		return nil, fmt.Errorf("malformed watermark %s : %w", filename, err)
+	}
+
+	w := &watermark{Date: date}
+	for _, line := range lines[1:] {
+		if ref := strings.TrimSpace(line); ref != "" {
+			if w.refs == nil {
+				w.refs = map[string]struct{}{}
+			}
+			w.refs[ref] = struct{}{}
+		}
+	}
+	return w, nil
+}
+
+// writeWatermark will store the watermark, file is replaced atomically,
+// so interrupted write never leaves malformed watermark.
+func writeWatermark(filename string, w *watermark) error {
+	refs := make([]string, 0, len(w.refs))
+	for ref := range w.refs {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	var content strings.Builder
+	content.WriteString(w.Date.Format(time.RFC3339Nano) + "\n")
+	for _, ref := range refs {
+		content.WriteString(ref + "\n")
+	}
+
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("unable to write watermark : %w", err)
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		return fmt.Errorf("unable to replace watermark : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// loadWithWatermark returns refs of events loaded with watermark stored in provided file and moves the watermark.
+func loadWithWatermark(t *testing.T, filename string, events ...*model.Event) []string {
+	t.Helper()
+	loaded, err := readWatermark(filename)
+	if err != nil {
+		t.Fatalf("unable to read watermark : %v", err)
+	}
+
+	latest := loaded.Clone()
+	var refs []string
+	for _, e := range events {
+		if loaded.Loaded(e) {
+			continue
+		}
+		latest.Advance(e)
+		refs = append(refs, e.EventRef)
+	}
+
+	if latest.Moved() {
+		if err := writeWatermark(filename, latest); err != nil {
+			t.Fatalf("unable to write watermark : %v", err)
+		}
+	}
+	return refs
+}
+
+// assertRefs fails the test if refs differ.
+func assertRefs(t *testing.T, expected, actual []string) {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Fatalf("expected refs %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("expected refs %v, got %v", expected, actual)
+		}
+	}
+}
+
+// datedEvent returns event with provided ref and date.
+func datedEvent(ref string, date time.Time) *model.Event {
+	return generator.NewEvent(generator.WithRef(ref), generator.WithDate(date))
+}
+
+func TestWatermarkFirstLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "watermark")
+	day := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	refs := loadWithWatermark(t, filename, datedEvent("ref-2", day.Add(time.Hour)), datedEvent("ref-1", day), datedEvent("ref-3", day.Add(time.Hour)))
+	assertRefs(t, []string{"ref-2", "ref-1", "ref-3"}, refs)
+
+	w, err := readWatermark(filename)
+	if err != nil {
+		t.Fatalf("unable to read watermark : %v", err)
+	}
+	if !w.Date.Equal(day.Add(time.Hour)) {
+		t.Errorf("expected watermark at %v, got %v", day.Add(time.Hour), w.Date)
+	}
+	if len(w.refs) != 2 {
+		t.Errorf("expected refs of the latest events ref-2 and ref-3, got %v", w.refs)
+	}
+}
+
+func TestWatermarkIncrementalLoad(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "watermark")
+	day := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	loadWithWatermark(t, filename, datedEvent("ref-1", day), datedEvent("ref-2", day.Add(time.Hour)))
+
+	refs := loadWithWatermark(t, filename,
+		// already loaded
+		datedEvent("ref-1", day),
+		datedEvent("ref-2", day.Add(time.Hour)),
+		// older than watermark
+		datedEvent("ref-0", day.Add(time.Minute)),
+		// the same date as watermark, but not loaded yet
+		datedEvent("ref-3", day.Add(time.Hour)),
+		datedEvent("ref-4", day.Add(2*time.Hour)),
+	)
+	assertRefs(t, []string{"ref-3", "ref-4"}, refs)
+
+	// nothing new, watermark stays
+	before, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read watermark : %v", err)
+	}
+	assertRefs(t, nil, loadWithWatermark(t, filename, datedEvent("ref-4", day.Add(2*time.Hour))))
+	after, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read watermark : %v", err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("expected watermark %q to stay, got %q", before, after)
+	}
+}
+
+func TestWatermarkWithoutRefs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "watermark")
+	day := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+	if err := os.WriteFile(filename, []byte(day.Format(time.RFC3339Nano)+"\n"), 0644); err != nil {
+		t.Fatalf("unable to write watermark : %v", err)
+	}
+
+	// events of the date are loaded by the run which stored watermark without refs
+	refs := loadWithWatermark(t, filename, datedEvent("ref-1", day), datedEvent("ref-2", day.Add(time.Second)))
+	assertRefs(t, []string{"ref-2"}, refs)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"os"
+	"time"
+)
+
+var (
+	salt = flag.String("salt", "", "secret salt of number hashing, the same salt gives the same pseudo numbers")
+)
+
+// Mask will replace calling and called numbers of generated dump with pseudo numbers,
+// so fixtures could be shared without real looking numbers while the call graph is preserved.
+//
+// arg 1 is path to file to mask, format is detected by extension
+// arg 2 is path to masked file, it's written in the same format
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -salt <salt> <input file> <output file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// log time duration on application shutdown
+	start := time.Now()
+	defer func() {
+		fmt.Println("================")
+		fmt.Printf("Execution Time : %v\n", time.Since(start))
+	}()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+	if *salt == "" {
+		panic(fmt.Errorf("salt is required, otherwise pseudo numbers could be restored by hashing all the numbers"))
+	}
+
+	inputFile := flag.Arg(0)
+	outPutFile := flag.Arg(1)
+	format := generator.FormatFromExt(inputFile)
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open input file : %+v", err))
+	}
+
+	events, err := generator.ReadEvents(bufio.NewReader(file), format)
+	file.Close()
+	if err != nil {
+		panic(fmt.Errorf("unable to read events : %+v", err))
+	}
+
+	sink, err := generator.NewFileSink(outPutFile, format, false, 0644)
+	if err != nil {
+		panic(fmt.Errorf("unable to create output file : %+v", err))
+	}
+	for _, e := range generator.Mask(events, *salt) {
+		if err := sink.Write(e); err != nil {
+			panic(fmt.Errorf("unable to write event : %+v", err))
+		}
+	}
+	if err := sink.Close(); err != nil {
+		panic(fmt.Errorf("unable to write events : %+v", err))
+	}
+
+	fmt.Printf("%d events masked to %s\n", len(events), outPutFile)
+}
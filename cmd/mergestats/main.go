@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/reporter"
+	"os"
+)
+
+// Mergestats will combine statistics files, e.g. collected from different machines, into a single history
+// ordered by execution start, the same runs present in several files are written once.
+//
+// arg 1 is path to merged statistics file, it could be one of inputs
+// args 2... are paths to statistics files to merge
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s <output file> <statistics file>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// validate inputs firstly
+	if flag.NArg() < 2 {
+		panic(fmt.Errorf("invalid number of arguments, at least 2 expected, got %d", flag.NArg()))
+	}
+
+	outFile := flag.Arg(0)
+	inFiles := flag.Args()[1:]
+	if err := reporter.Merge(outFile, inFiles...); err != nil {
+		panic(fmt.Errorf("unable to merge statistics : %+v", err))
+	}
+
+	stats, err := reporter.GetAllStatistics(outFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to read merged statistics : %+v", err))
+	}
+	fmt.Printf("%d runs of %d files merged to %s\n", len(stats), len(inFiles), outFile)
+}
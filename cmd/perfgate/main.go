@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/reporter"
+	"os"
+	"strconv"
+)
+
+var (
+	maxRegress = flag.Float64("max-regress", 10, "max allowed slowdown of the latest run in percents of median of the previous runs")
+)
+
+// Perfgate will exit with code 1 if the latest run is slower than median of the previous runs with the same number
+// of events by more than allowed percentage, so performance regressions fail CI.
+//
+// arg 1 is path to statistics file
+// arg 2 is number of events of compared runs
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <statistics file> <number of events>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+	eventCount, err := strconv.Atoi(flag.Arg(1))
+	if err != nil {
+		panic(fmt.Errorf("invalid number of events '%s' : %+v", flag.Arg(1), err))
+	}
+	if *maxRegress < 0 {
+		panic(fmt.Errorf("max regression must not be negative, got %v", *maxRegress))
+	}
+
+	regressed, err := reporter.CheckRegression(flag.Arg(0), eventCount, *maxRegress)
+	if err != nil {
+		panic(fmt.Errorf("unable to check regression : %+v", err))
+	}
+	if regressed {
+		fmt.Printf("latest run of %s events is more than %.2f%% slower than median of previous runs\n", reporter.FormatNumber(eventCount), *maxRegress)
+		os.Exit(1)
+	}
+	fmt.Printf("latest run of %s events is within %.2f%% of median of previous runs\n", reporter.FormatNumber(eventCount), *maxRegress)
+}
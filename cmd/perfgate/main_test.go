@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/reporter"
+)
+
+// argsEnv passes perfgate arguments to the test binary re-executed as perfgate.
+const argsEnv = "PERFGATE_TEST_ARGS"
+
+// runPerfgate runs perfgate with provided arguments in a separate process, returns its output and exit code.
+func runPerfgate(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=TestPerfgateProcess")
+	cmd.Env = append(os.Environ(), argsEnv+"="+strings.Join(args, "\n"))
+	output, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(output), exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatalf("unable to run perfgate : %v", err)
+	}
+	return string(output), 0
+}
+
+// TestPerfgateProcess runs perfgate main when the test binary is executed by runPerfgate.
+func TestPerfgateProcess(t *testing.T) {
+	args, ok := os.LookupEnv(argsEnv)
+	if !ok {
+		t.Skip("executed by runPerfgate only")
+	}
+	os.Args = append([]string{"perfgate"}, strings.Split(args, "\n")...)
+	main()
+	os.Exit(0)
+}
+
+func TestPerfgateExitCode(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	start := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+	for i, duration := range []time.Duration{4 * time.Second, 3 * time.Second, 5 * time.Second} {
+		stat := reporter.ExecutionStatistic{ExecutionStart: start.Add(time.Duration(i) * time.Hour), NumbOfEvents: 1000, Duration: duration}
+		if err := reporter.Save(filename, stat); err != nil {
+			t.Fatalf("unable to save statistic : %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		maxRegress string
+		expected   int
+	}{
+		// the latest 5s run is ~43% slower than 3.5s median of the previous runs
+		{name: "pass", maxRegress: "50", expected: 0},
+		{name: "fail", maxRegress: "10", expected: 1},
+	}
+	for _, test := range tests {
+		output, code := runPerfgate(t, "-max-regress", test.maxRegress, filename, "1000")
+		if code != test.expected {
+			t.Errorf("%s : expected exit code %d, got %d, output:\n%s", test.name, test.expected, code, output)
+		}
+	}
+}
+
+func TestPerfgateFailsWithoutRuns(t *testing.T) {
+	output, code := runPerfgate(t, filepath.Join(t.TempDir(), "missing.json"), "1000")
+	if code == 0 {
+		t.Errorf("expected missing statistics to fail the gate, output:\n%s", output)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// supported sinks.
+const (
+	sinkStdout = "stdout"
+	sinkKafka  = "kafka"
+)
+
+var (
+	speed        = flag.Float64("speed", 1, "how many times replay is faster than recorded time, e.g. 10")
+	maxGap       = flag.Duration("max-gap", 0, "cap of recorded gap between events before scaling, so sparse dumps don't stall, not capped if not set")
+	sinkType     = flag.String("sink", sinkStdout, "destination of replayed events: stdout or kafka")
+	format       = flag.String("format", generator.FormatNDJSON, "format of events written to stdout: ndjson or csv")
+	kafkaBrokers = flag.String("kafka-brokers", "localhost:9092", "comma separated kafka brokers of kafka sink")
+	kafkaTopic   = flag.String("kafka-topic", "events", "topic of kafka sink")
+)
+
+// Replay will emit events of recorded dump to a sink as if they were happening now, events are spaced according
+// to gaps between their dates scaled by speed, so consumers could be tested against a realistic live stream.
+//
+// Events are sorted by date before replay, so the whole dump is read in memory, ~500 bytes per event.
+//
+// arg 1 is path to dump to replay, format is detected by extension
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <input file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// log time duration on application shutdown, standard output may be the replayed stream
+	start := time.Now()
+	defer func() {
+		fmt.Fprintln(os.Stderr, "================")
+		fmt.Fprintf(os.Stderr, "Execution Time : %v\n", time.Since(start))
+	}()
+
+	// validate inputs firstly
+	if flag.NArg() != 1 {
+		panic(fmt.Errorf("invalid number of arguments, 1 expected, got %d", flag.NArg()))
+	}
+	if *speed <= 0 {
+		panic(fmt.Errorf("speed must be positive, got %v", *speed))
+	}
+	if *maxGap < 0 {
+		panic(fmt.Errorf("max gap must not be negative, got %v", *maxGap))
+	}
+
+	inputFile := flag.Arg(0)
+	file, err := os.Open(inputFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open input file : %+v", err))
+	}
+	events, err := generator.ReadEvents(bufio.NewReader(file), generator.FormatFromExt(inputFile))
+	file.Close()
+	if err != nil {
+		panic(fmt.Errorf("unable to read events : %+v", err))
+	}
+
+	// recorded events are not necessarily ordered
+	events.SortByDate()
+
+	sink, err := openSink()
+	if err != nil {
+		panic(fmt.Errorf("unable to open sink : %+v", err))
+	}
+
+	// stop replay gracefully on interruption
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	replayed, err := replay(ctx, events, sink)
+	if closeErr := sink.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		panic(fmt.Errorf("unable to replay events : %+v", err))
+	}
+
+	if replayed < len(events) {
+		fmt.Fprintf(os.Stderr, "replay cancelled, %d of %d events replayed\n", replayed, len(events))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%d events replayed\n", replayed)
+}
+
+// replay will write events sorted by date to sink at scaled time of their dates.
+// Events are scheduled relative to replay start, so slow writes don't accumulate delay.
+// Returns number of replayed events.
+func replay(ctx context.Context, events model.Events, sink generator.Sink) (int, error) {
+	flusher, _ := sink.(generator.Flusher)
+
+	start := time.Now()
+	var offset time.Duration
+	for i, e := range events {
+		if i > 0 {
+			gap := e.EventDate.Sub(events[i-1].EventDate)
+			if *maxGap > 0 && gap > *maxGap {
+				gap = *maxGap
+			}
+			offset += time.Duration(float64(gap) / *speed)
+		}
+
+		if wait := time.Until(start.Add(offset)); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return i, nil
+			case <-timer.C:
+			}
+		}
+
+		if err := sink.Write(e); err != nil {
+			return i, fmt.Errorf("unable to write event : %w", err)
+		}
+		// replayed events must reach the reader immediately
+		if flusher != nil {
+			if err := flusher.Flush(); err != nil {
+				return i, fmt.Errorf("unable to flush sink : %w", err)
+			}
+		}
+	}
+	return len(events), nil
+}
+
+// openSink creates configured sink of replayed events.
+func openSink() (generator.Sink, error) {
+	switch *sinkType {
+	case sinkStdout:
+		if *format != generator.FormatNDJSON && *format != generator.FormatCSV {
+			return nil, fmt.Errorf("format '%s' can't be streamed, ndjson or csv expected", *format)
+		}
+		return generator.NewStdoutSink(*format)
+	case sinkKafka:
+		var brokers []string
+		for _, broker := range strings.Split(*kafkaBrokers, ",") {
+			if broker = strings.TrimSpace(broker); broker != "" {
+				brokers = append(brokers, broker)
+			}
+		}
+		return generator.NewKafkaSink(brokers, *kafkaTopic)
+	}
+	return nil, fmt.Errorf("unknown sink '%s'", *sinkType)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/reporter"
+	"os"
+)
+
+var (
+	hist         = flag.Bool("hist", false, "print histogram of durations for every number of events")
+	buckets      = flag.Int("buckets", 10, "number of histogram buckets")
+	compare      = flag.String("compare", "", "statistics file to compare with, provided statistics file is the baseline")
+	watch        = flag.Bool("watch", false, "follow statistics file and print every new run as it is saved")
+	table        = flag.Bool("table", false, "print every run as a row of aligned table")
+	noColor      = flag.Bool("no-color", false, "disable colors, they are disabled automatically if output is not a terminal or NO_COLOR is set")
+	exportJSON   = flag.String("export-json", "", "write all the runs to provided file as a json array, - for stdout")
+	thousandsSep = flag.String("thousands-sep", "", "thousands separator of reported numbers, e.g. ' ' or ., picked by LC_NUMERIC locale if not set, comma by default")
+)
+
+// Report will print summary of execution statistics stored in provided file.
+//
+// arg 1 - statistics file
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <statistics file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// validate inputs firstly
+	if flag.NArg() != 1 {
+		panic(fmt.Errorf("invalid number of arguments, 1 expected, got %d", flag.NArg()))
+	}
+
+	if *noColor {
+		reporter.Colors = false
+	}
+	if *thousandsSep != "" {
+		reporter.Separator = *thousandsSep
+	}
+
+	if *watch {
+		if err := reporter.Watch(flag.Arg(0)); err != nil {
+			panic(fmt.Errorf("unable to watch statistics : %+v", err))
+		}
+		return
+	}
+
+	if *compare != "" {
+		if err := reporter.Compare(flag.Arg(0), *compare); err != nil {
+			panic(fmt.Errorf("unable to compare statistics : %+v", err))
+		}
+		return
+	}
+
+	if *exportJSON == "-" {
+		stats, err := reporter.GetAllStatistics(flag.Arg(0))
+		if err != nil {
+			panic(fmt.Errorf("unable to read statistics : %+v", err))
+		}
+		if err := reporter.ExportJSON(stats, os.Stdout); err != nil {
+			panic(fmt.Errorf("unable to export statistics : %+v", err))
+		}
+		return
+	}
+	if *exportJSON != "" {
+		if err := reporter.ExportJSONFile(flag.Arg(0), *exportJSON); err != nil {
+			panic(fmt.Errorf("unable to export statistics : %+v", err))
+		}
+		return
+	}
+
+	if *table {
+		stats, err := reporter.GetAllStatistics(flag.Arg(0))
+		if err != nil {
+			panic(fmt.Errorf("unable to read statistics : %+v", err))
+		}
+		if err := reporter.PrintTable(os.Stdout, stats); err != nil {
+			panic(fmt.Errorf("unable to print statistics : %+v", err))
+		}
+		return
+	}
+
+	err := reporter.Report(os.Stdout, flag.Arg(0), reporter.ReportOptions{
+		Histogram: *hist,
+		Buckets:   *buckets,
+	})
+	if errors.Is(err, reporter.ErrStatisticsNotFound) {
+		fmt.Printf("no statistics found : %+v\n", err)
+		return
+	}
+	if err != nil {
+		panic(fmt.Errorf("unable to build report : %+v", err))
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	format = flag.String("format", "auto", "input format: json (single array), ndjson (event per line) or auto to detect by file extension")
+)
+
+// chunk is a single output file.
+type chunk struct {
+	name    string
+	file    *os.File
+	w       *bufio.Writer
+	bytes   int64
+	records int
+}
+
+// Split will split existing dump into provided number of chunks of roughly equal size for parallel loading.
+// Records are never split, every chunk of json array dump is a valid json array itself.
+// File is streamed, so it doesn't have to fit in memory.
+//
+// arg 1 - input file
+// arg 2 - number of chunks
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <input file> <number of chunks>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+
+	inputFile := flag.Arg(0)
+	numChunks, err := strconv.Atoi(flag.Arg(1))
+	if err != nil || numChunks < 1 {
+		panic(fmt.Errorf("number of chunks must be a positive integer, got '%s'", flag.Arg(1)))
+	}
+
+	inputFormat := *format
+	if inputFormat == "auto" {
+		inputFormat = generator.FormatFromExt(inputFile)
+	}
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open input file : %+v", err))
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		panic(fmt.Errorf("unable to stat input file : %+v", err))
+	}
+	// chunk is switched once it reaches its share of input
+	chunkSize := info.Size()/int64(numChunks) + 1
+
+	chunks := make([]*chunk, 0, numChunks)
+	for i := 1; i <= numChunks; i++ {
+		c, err := createChunk(inputFile, i)
+		if err != nil {
+			panic(err)
+		}
+		chunks = append(chunks, c)
+	}
+
+	// write is called for every record, it picks the chunk to write to
+	current := 0
+	write := func(record []byte) error {
+		if chunks[current].bytes >= chunkSize && current < len(chunks)-1 {
+			current++
+		}
+		return chunks[current].write(record, inputFormat)
+	}
+
+	switch inputFormat {
+	case "ndjson":
+		err = splitNDJSON(file, write)
+	case "json":
+		err = splitJSON(file, write)
+	default:
+		err = fmt.Errorf("unknown input format '%s'", inputFormat)
+	}
+	if err != nil {
+		panic(fmt.Errorf("unable to split file : %+v", err))
+	}
+
+	for _, c := range chunks {
+		if err := c.close(inputFormat); err != nil {
+			panic(fmt.Errorf("unable to write chunk %s : %+v", c.name, err))
+		}
+		fmt.Printf("%s : %d records\n", c.name, c.records)
+	}
+}
+
+// createChunk creates i-th chunk file named like events.0001.ndjson.
+func createChunk(inputFile string, i int) (*chunk, error) {
+	name := generator.NumberedName(inputFile, i)
+
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create chunk %s : %w", name, err)
+	}
+	return &chunk{name: name, file: file, w: bufio.NewWriter(file)}, nil
+}
+
+// write will append a single record to the chunk.
+func (c *chunk) write(record []byte, format string) error {
+	if format == "json" {
+		sep := ","
+		if c.records == 0 {
+			sep = "["
+		}
+		if _, err := c.w.WriteString(sep); err != nil {
+			return err
+		}
+		c.bytes++
+	}
+
+	n, err := c.w.Write(record)
+	if err != nil {
+		return err
+	}
+	c.bytes += int64(n)
+
+	if format == "ndjson" {
+		if err := c.w.WriteByte('\n'); err != nil {
+			return err
+		}
+		c.bytes++
+	}
+
+	c.records++
+	return nil
+}
+
+// close will finish and close the chunk file.
+func (c *chunk) close(format string) error {
+	defer c.file.Close()
+
+	if format == "json" {
+		end := "]"
+		if c.records == 0 {
+			end = "[]"
+		}
+		if _, err := c.w.WriteString(end); err != nil {
+			return err
+		}
+	}
+	return c.w.Flush()
+}
+
+// splitNDJSON will call write for every non empty line.
+func splitNDJSON(r io.Reader, write func(record []byte) error) error {
+	scanner := bufio.NewScanner(bufio.NewReader(r))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		if err := write(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// splitJSON will call write for every element of json array.
+func splitJSON(r io.Reader, write func(record []byte) error) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("unable to read array start : %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("json array expected, got %v", token)
+	}
+
+	for dec.More() {
+		var record json.RawMessage
+		if err := dec.Decode(&record); err != nil {
+			return fmt.Errorf("unable to read record : %w", err)
+		}
+		if err := write(record); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	dialectName = flag.String("dialect", "postgres", "SQL dialect of the script: postgres, mysql or sqlite3")
+	batchSize   = flag.Int("batch-size", 1000, "number of events inserted with a single statement")
+	keepTime    = flag.Bool("keep-time", true, "keep time of day and local time of event date zone, -keep-time=false stores only the date")
+	initSchema  = flag.Bool("init-schema", false, "start the script with DDL creating event table if it does not exist")
+)
+
+// eventColumns are columns of event table in order of inserted values.
+const eventColumns = `event_source, event_ref, event_type, event_date, calling_number, called_number, location,
+                  duration_seconds, attr_1, attr_2, attr_3, attr_4, attr_5, attr_6, attr_7, attr_8`
+
+// SQL dump will convert generated dump to SQL script of batched insert statements,
+// thus events could be loaded where only SQL scripts can be run.
+//
+// arg 1 is path to file to convert, format is detected by extension
+// arg 2 is path to SQL script to write
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <input file> <output file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// log time duration on application shutdown
+	start := time.Now()
+	defer func() {
+		fmt.Println("================")
+		fmt.Printf("Execution Time : %v\n", time.Since(start))
+	}()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+	if *batchSize < 1 {
+		panic(fmt.Errorf("invalid batch size %d, positive number expected", *batchSize))
+	}
+
+	inputFile := flag.Arg(0)
+	outPutFile := flag.Arg(1)
+
+	d, err := dialect.ByName(*dialectName)
+	if err != nil {
+		panic(err)
+	}
+
+	in, err := os.Open(inputFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open input file : %+v", err))
+	}
+	defer in.Close()
+
+	out, err := os.Create(outPutFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to create output file : %+v", err))
+	}
+	defer out.Close()
+	w := bufio.NewWriter(out)
+
+	if *initSchema {
+		for _, stmt := range d.Schema() {
+			fmt.Fprintf(w, "%s;\n", strings.TrimSpace(stmt))
+		}
+		fmt.Fprintln(w)
+	}
+
+	written := 0
+	batch := make(model.Events, 0, *batchSize)
+	add := func(e *model.Event) error {
+		batch = append(batch, e)
+		if len(batch) < *batchSize {
+			return nil
+		}
+		written += len(batch)
+		err := writeInsert(w, d, batch)
+		batch = batch[:0]
+		return err
+	}
+
+	if err := readEvents(bufio.NewReader(in), generator.FormatFromExt(inputFile), add); err != nil {
+		panic(fmt.Errorf("unable to convert events : %+v", err))
+	}
+	written += len(batch)
+	if err := writeInsert(w, d, batch); err != nil {
+		panic(fmt.Errorf("unable to write events : %+v", err))
+	}
+
+	if err := w.Flush(); err != nil {
+		panic(fmt.Errorf("unable to write events : %+v", err))
+	}
+	fmt.Printf("%d events written to %s\n", written, outPutFile)
+}
+
+// readEvents will call fn for every event read from r in provided format.
+func readEvents(r io.Reader, format string, fn func(e *model.Event) error) error {
+	if format == generator.FormatJSON {
+		return generator.StreamJSON(r, fn)
+	}
+
+	events, err := generator.ReadEvents(r, format)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeInsert will write a single multi-row insert statement of provided events.
+func writeInsert(w io.Writer, d dialect.Dialect, events model.Events) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var q strings.Builder
+	q.WriteString("insert into event(" + eventColumns + ")\nvalues ")
+	for i, e := range events {
+		if i > 0 {
+			q.WriteString(",\n       ")
+		}
+
+		date := d.UnixToDate(e.EventDate.Unix())
+		if *keepTime {
+			date = d.Timestamp(e.EventDate)
+		}
+
+		values := []string{
+			strconv.Itoa(e.EventSource),
+			d.Quote(e.EventRef),
+			strconv.Itoa(int(e.EventType)),
+			date,
+			strconv.Itoa(e.CallingNumber),
+			strconv.Itoa(e.CalledNumber),
+			d.Quote(e.Location),
+			strconv.Itoa(e.DurationSeconds),
+		}
+		for _, attr := range e.Attributes() {
+			values = append(values, d.Quote(attr))
+		}
+		fmt.Fprintf(&q, "(%s)", strings.Join(values, ", "))
+	}
+	q.WriteString(";\n")
+
+	_, err := io.WriteString(w, q.String())
+	return err
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+var update = flag.Bool("update", false, "update golden files of testdata")
+
+// insertEvents are events with special characters in string values.
+func insertEvents() model.Events {
+	return model.Events{
+		{
+			EventSource:     1,
+			EventRef:        "0b6a6c4e-3f0e-4e8c-9a57-1f2b3c4d5e6f",
+			EventType:       model.EventTypeSMS,
+			EventDate:       time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC),
+			CallingNumber:   79001234567,
+			CalledNumber:    79007654321,
+			Location:        "Cote d'Ivoire",
+			DurationSeconds: 12,
+			Attr1:           "O'Brien",
+			Attr2:           `C:\calls`,
+		},
+		{
+			EventSource:     2,
+			EventRef:        "1c7b7d5f-4a1f-4f9d-8b68-2a3c4d5e6f70",
+			EventType:       model.EventTypeRoaming,
+			EventDate:       time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC),
+			CallingNumber:   79001111111,
+			CalledNumber:    79002222222,
+			Location:        "ABC",
+			DurationSeconds: 0,
+			Attr8:           `\'`,
+		},
+	}
+}
+
+func TestWriteInsert(t *testing.T) {
+	for _, d := range []dialect.Dialect{dialect.Postgres{}, dialect.MySQL{}, dialect.SQLite{}} {
+		var buf bytes.Buffer
+		if err := writeInsert(&buf, d, insertEvents()); err != nil {
+			t.Fatalf("unable to write insert : %v", err)
+		}
+
+		golden := filepath.Join("testdata", "insert."+d.Name()+".golden.sql")
+		if *update {
+			if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+				t.Fatalf("unable to update golden file : %v", err)
+			}
+		}
+		expected, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("unable to read golden file : %v", err)
+		}
+		if !bytes.Equal(expected, buf.Bytes()) {
+			t.Errorf("insert differs from %s, run go test -update to accept it\nexpected:\n%s\nactual:\n%s", golden, expected, buf.Bytes())
+		}
+	}
+}
+
+func TestWriteInsertOfNoEvents(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeInsert(&buf, dialect.Postgres{}, nil); err != nil {
+		t.Fatalf("unable to write insert : %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no statement, got %s", buf.String())
+	}
+}
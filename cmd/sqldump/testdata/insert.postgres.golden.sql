@@ -0,0 +1,4 @@
+insert into event(event_source, event_ref, event_type, event_date, calling_number, called_number, location,
+                  duration_seconds, attr_1, attr_2, attr_3, attr_4, attr_5, attr_6, attr_7, attr_8)
+values (1, '0b6a6c4e-3f0e-4e8c-9a57-1f2b3c4d5e6f', 2, cast('2022-03-14 15:09:26' as timestamp), 79001234567, 79007654321, 'Cote d''Ivoire', 12, 'O''Brien', 'C:\calls', '', '', '', '', '', ''),
+       (2, '1c7b7d5f-4a1f-4f9d-8b68-2a3c4d5e6f70', 4, cast('2022-03-15 00:00:00' as timestamp), 79001111111, 79002222222, 'ABC', 0, '', '', '', '', '', '', '', '\''');
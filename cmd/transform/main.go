@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// batchSize is number of events transformed at once.
+const batchSize = 1000
+
+var (
+	dropTypes   = flag.String("drop-type", "", "comma separated event types to drop, e.g. 5 or 3,5")
+	onlyTypes   = flag.String("only-type", "", "comma separated event types to keep, the rest are dropped")
+	maxDuration = flag.Int("max-duration", 0, "cap durations longer than provided number of seconds, not capped if not set")
+)
+
+// Transform will write a variant of existing dump with events filtered and changed according to flags,
+// so dataset doesn't have to be regenerated. File is streamed, so it doesn't have to fit in memory.
+//
+// arg 1 is path to file to transform, format is detected by extension
+// arg 2 is path to transformed file, format is detected by extension
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <input file> <output file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// log time duration on application shutdown
+	start := time.Now()
+	defer func() {
+		fmt.Println("================")
+		fmt.Printf("Execution Time : %v\n", time.Since(start))
+	}()
+
+	// validate inputs firstly
+	if flag.NArg() != 2 {
+		panic(fmt.Errorf("invalid number of arguments, 2 expected, got %d", flag.NArg()))
+	}
+
+	drop, err := parseTypes(*dropTypes)
+	if err != nil {
+		panic(fmt.Errorf("invalid drop types : %+v", err))
+	}
+	only, err := parseTypes(*onlyTypes)
+	if err != nil {
+		panic(fmt.Errorf("invalid only types : %+v", err))
+	}
+	if *maxDuration < 0 {
+		panic(fmt.Errorf("max duration must not be negative, got %d", *maxDuration))
+	}
+
+	keep := func(e *model.Event) bool {
+		if _, ok := drop[e.EventType]; ok {
+			return false
+		}
+		if _, ok := only[e.EventType]; len(only) > 0 && !ok {
+			return false
+		}
+		return true
+	}
+
+	inputFile := flag.Arg(0)
+	outPutFile := flag.Arg(1)
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to open input file : %+v", err))
+	}
+	defer file.Close()
+
+	sink, err := generator.NewFileSink(outPutFile, generator.FormatFromExt(outPutFile), false, 0644)
+	if err != nil {
+		panic(fmt.Errorf("unable to create output file : %+v", err))
+	}
+
+	read, kept := 0, 0
+	batch := make(model.Events, 0, batchSize)
+	flush := func() error {
+		for _, e := range batch.Filter(keep) {
+			if *maxDuration > 0 && e.DurationSeconds > *maxDuration {
+				e.DurationSeconds = *maxDuration
+			}
+			if err := sink.Write(e); err != nil {
+				return err
+			}
+			kept++
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	skipped, err := generator.StreamEvents(bufio.NewReader(file), generator.FormatFromExt(inputFile), func(e *model.Event) error {
+		read++
+		batch = append(batch, e)
+		if len(batch) < batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		panic(fmt.Errorf("unable to transform events : %+v", err))
+	}
+	if err := sink.Close(); err != nil {
+		panic(fmt.Errorf("unable to write events : %+v", err))
+	}
+
+	if skipped > 0 {
+		fmt.Printf("skipped %d malformed events\n", skipped)
+	}
+	fmt.Printf("%d events kept, %d dropped, written to %s\n", kept, read-kept, outPutFile)
+}
+
+// parseTypes parses comma separated event types like 3,5.
+func parseTypes(s string) (map[model.EventType]struct{}, error) {
+	types := map[model.EventType]struct{}{}
+	if s == "" {
+		return types, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		t, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid event type '%s' : %w", part, err)
+		}
+		if !model.EventType(t).IsValid() {
+			return nil, fmt.Errorf("unknown event type %d", t)
+		}
+		types[model.EventType(t)] = struct{}{}
+	}
+	return types, nil
+}
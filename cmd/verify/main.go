@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/dialect"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"github.com/xo/dburl"
+	"os"
+	"sort"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var (
+	format = flag.String("format", generator.FormatAuto, "input format: json (single array), ndjson (event per line), csv or auto to detect by file extension")
+)
+
+// Verify will compare events stored in the generated dump with events loaded to provided DB.
+// Number of events and checksum of sorted event refs are compared, thus rows silently skipped
+// during loading will be detected.
+//
+// arg 1 is DB URL for database to verify
+// arg 2.. are paths to files which were loaded, e.g. rolled events.0001.ndjson events.0002.ndjson
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <database url> <input file>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// validate inputs firstly
+	if flag.NArg() < 2 {
+		panic(fmt.Errorf("invalid number of arguments, at least 2 expected, got %d", flag.NArg()))
+	}
+
+	dbUrl := flag.Arg(0)
+	inputFiles := flag.Args()[1:]
+
+	url, err := dburl.Parse(dbUrl)
+	if err != nil {
+		panic(fmt.Errorf("unable to parse database URL '%s' : %+v", dbUrl, err))
+	}
+
+	db, _, err := dialect.Open(context.Background(), url)
+	if err != nil {
+		panic(fmt.Errorf("unable to connect to database : %+v", err))
+	}
+	defer db.Close()
+
+	fileRefs, err := readRefs(*format, inputFiles)
+	if err != nil {
+		panic(fmt.Errorf("unable to read event files : %+v", err))
+	}
+
+	dbRefs, err := loadRefs(db)
+	if err != nil {
+		panic(fmt.Errorf("unable to read event refs from database : %+v", err))
+	}
+
+	fileChecksum := checksum(fileRefs)
+	dbChecksum := checksum(dbRefs)
+
+	fmt.Printf("files    : %d events, checksum %s\n", len(fileRefs), fileChecksum)
+	fmt.Printf("database : %d events, checksum %s\n", len(dbRefs), dbChecksum)
+
+	if len(fileRefs) != len(dbRefs) || fileChecksum != dbChecksum {
+		fmt.Println("MISMATCH")
+		os.Exit(1)
+	}
+	fmt.Println("OK")
+}
+
+// readRefs will read refs of all the events stored in provided files, events are streamed,
+// so only refs are kept in memory.
+func readRefs(format string, inputFiles []string) ([]string, error) {
+	var refs []string
+	for _, inputFile := range inputFiles {
+		inputFormat, err := generator.DetectFormat(format, inputFile, generator.FormatJSON, generator.FormatNDJSON, generator.FormatCSV)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := os.Open(inputFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open input file : %w", err)
+		}
+
+		skipped, err := generator.StreamEvents(bufio.NewReader(file), inputFormat, func(e *model.Event) error {
+			refs = append(refs, e.EventRef)
+			return nil
+		})
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s : %w", inputFile, err)
+		}
+		if skipped > 0 {
+			fmt.Printf("skipped %d malformed events of %s\n", skipped, inputFile)
+		}
+	}
+	return refs, nil
+}
+
+// loadRefs will read refs of all the events stored in database.
+func loadRefs(db *sql.DB) ([]string, error) {
+	rows, err := db.Query("select event_ref from event")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []string
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// checksum will calculate SHA-256 of sorted refs.
+// Refs are sorted on client side, so database collation doesn't affect the result.
+func checksum(refs []string) string {
+	sorted := make([]string, len(refs))
+	copy(sorted, refs)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for _, ref := range sorted {
+		h.Write([]byte(ref))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
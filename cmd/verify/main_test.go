@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+)
+
+// writeFile writes events to the file in format detected by its extension.
+func writeFile(t *testing.T, filename string, refs ...string) {
+	t.Helper()
+	sink, err := generator.NewFileSink(filename, generator.FormatFromExt(filename), false, 0644)
+	if err != nil {
+		t.Fatalf("unable to create %s : %v", filename, err)
+	}
+	for _, ref := range refs {
+		if err := sink.Write(generator.NewEvent(generator.WithRef(ref))); err != nil {
+			t.Fatalf("unable to write event : %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("unable to close %s : %v", filename, err)
+	}
+}
+
+func TestReadRefsOfMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{
+		filepath.Join(dir, "events.0001.ndjson"),
+		filepath.Join(dir, "events.0002.ndjson"),
+		filepath.Join(dir, "events.csv"),
+		filepath.Join(dir, "events.json"),
+	}
+	writeFile(t, files[0], "ref-1", "ref-2")
+	writeFile(t, files[1], "ref-3")
+	writeFile(t, files[2], "ref-4")
+	writeFile(t, files[3], "ref-5")
+
+	refs, err := readRefs(generator.FormatAuto, files)
+	if err != nil {
+		t.Fatalf("unable to read refs : %v", err)
+	}
+
+	expected := []string{"ref-1", "ref-2", "ref-3", "ref-4", "ref-5"}
+	if !reflect.DeepEqual(refs, expected) {
+		t.Errorf("expected refs %v, got %v", expected, refs)
+	}
+	if checksum(refs) != checksum([]string{"ref-5", "ref-4", "ref-3", "ref-2", "ref-1"}) {
+		t.Error("expected checksum not to depend on order of refs")
+	}
+}
+
+func TestReadRefsWithExplicitFormat(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.txt")
+	if err := os.WriteFile(filename, []byte("{\"event_ref\":\"ref-1\"}\n"), 0644); err != nil {
+		t.Fatalf("unable to write file : %v", err)
+	}
+
+	refs, err := readRefs(generator.FormatNDJSON, []string{filename})
+	if err != nil {
+		t.Fatalf("unable to read refs : %v", err)
+	}
+	if !reflect.DeepEqual(refs, []string{"ref-1"}) {
+		t.Errorf("expected ref-1, got %v", refs)
+	}
+}
+
+func TestReadRefsRejectsUnsupportedFormat(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.cdr")
+	writeFile(t, filename, "ref-1")
+
+	if _, err := readRefs(generator.FormatAuto, []string{filename}); !errors.Is(err, generator.ErrInvalidArgs) {
+		t.Errorf("expected cdr format to be rejected, got %v", err)
+	}
+}
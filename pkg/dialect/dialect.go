@@ -0,0 +1,308 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/xo/dburl"
+)
+
+// Dialect describes database specific parts of the queries used to work with events.
+type Dialect interface {
+	// Name is the name of database driver to be used with sql.Open.
+	Name() string
+	// Placeholder returns bind parameter placeholder for the n-th (starting from 1) query argument.
+	Placeholder(n int) string
+	// UnixToDate returns SQL expression converting provided unix epoch seconds to the event date.
+	UnixToDate(epoch int64) string
+	// Timestamp returns SQL expression of provided time as a timestamp without time zone.
+	// Wall clock of the time in its own location is kept, so generated local time survives loading.
+	Timestamp(t time.Time) string
+	// Schema returns DDL statements creating event table if it does not exist.
+	Schema() []string
+	// Quote returns string literal of provided value with special characters escaped.
+	Quote(s string) string
+	// Explain returns statement showing plan of provided query without executing it.
+	Explain(query string) string
+	// ExistingRefsQuery returns query selecting refs of stored events out of provided ones and its arguments.
+	ExistingRefsQuery(refs []string) (string, []interface{})
+}
+
+var (
+	// ErrUnsupported is returned for databases without dialect.
+	ErrUnsupported = errors.New("unsupported database")
+	// ErrDBConnection is wrapped by errors of connecting to database.
+	ErrDBConnection = errors.New("database connection failed")
+)
+
+//go:embed schema/*.sql
+var schemas embed.FS
+
+// loadSchema will read embedded DDL file and split it into separate statements,
+// thus every statement could be executed independently of driver multi statements support.
+func loadSchema(name string) []string {
+	content, err := schemas.ReadFile("schema/" + name)
+	if err != nil {
+		// files are embedded during build, so it's a programming error
+		panic(fmt.Errorf("unable to read schema %s : %+v", name, err))
+	}
+
+	var statements []string
+	for _, stmt := range strings.Split(string(content), ";") {
+		if strings.TrimSpace(stripComments(stmt)) != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+// stripComments will remove single line comments from the statement.
+func stripComments(stmt string) string {
+	lines := strings.Split(stmt, "\n")
+	for i, line := range lines {
+		if idx := strings.Index(line, "--"); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ForURL returns dialect of the database provided URL points to.
+func ForURL(url *dburl.URL) (Dialect, error) {
+	switch url.Driver {
+	case "postgres":
+		return Postgres{}, nil
+	case "mysql":
+		return MySQL{}, nil
+	case "sqlite3":
+		return SQLite{}, nil
+	}
+	return nil, fmt.Errorf("driver '%s' : %w", url.Driver, ErrUnsupported)
+}
+
+// Open will connect to database provided URL points to and return its dialect.
+// Connection is checked, so unreachable database is reported with ErrDBConnection.
+// Driver of the database must be registered by the caller.
+func Open(ctx context.Context, url *dburl.URL) (*sql.DB, Dialect, error) {
+	d, err := ForURL(url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := sql.Open(d.Name(), url.DSN)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v : %w", err, ErrDBConnection)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("%v : %w", err, ErrDBConnection)
+	}
+	return db, d, nil
+}
+
+// ByName returns dialect by its driver name, sqlite is accepted as an alias of sqlite3.
+func ByName(name string) (Dialect, error) {
+	switch name {
+	case "postgres":
+		return Postgres{}, nil
+	case "mysql":
+		return MySQL{}, nil
+	case "sqlite3", "sqlite":
+		return SQLite{}, nil
+	}
+	return nil, fmt.Errorf("dialect '%s', postgres, mysql or sqlite3 expected : %w", name, ErrUnsupported)
+}
+
+// Placeholders returns comma separated placeholders for arguments from `from` to `to` inclusively.
+func Placeholders(d Dialect, from, to int) string {
+	placeholders := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		placeholders = append(placeholders, d.Placeholder(i))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// timestampLayout is layout of timestamp literals understood by all supported databases.
+const timestampLayout = "2006-01-02 15:04:05.999999"
+
+// ParseTimestamp converts event date scanned from database to time of provided location.
+// Drivers return either time.Time or text of timestamp depending on column type and connection settings,
+// event dates are stored without time zone, so wall clock of the value is kept.
+func ParseTimestamp(v interface{}, loc *time.Location) (time.Time, error) {
+	switch value := v.(type) {
+	case time.Time:
+		return time.Date(value.Year(), value.Month(), value.Day(), value.Hour(), value.Minute(), value.Second(), value.Nanosecond(), loc), nil
+	case []byte:
+		return ParseTimestamp(string(value), loc)
+	case string:
+		for _, layout := range []string{timestampLayout, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+			if t, err := time.ParseInLocation(layout, strings.TrimSuffix(value, "Z"), loc); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("unable to parse timestamp '%s'", value)
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamp value %v of type %T", v, v)
+}
+
+// retryableCodes are SQLSTATE codes of serialization failure and deadlock, transaction could succeed if retried.
+var retryableCodes = map[pq.ErrorCode]bool{
+	"40001": true,
+	"40P01": true,
+}
+
+// Retryable reports whether err is postgres serialization failure or deadlock, so failed statement could be retried.
+func Retryable(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && retryableCodes[pqErr.Code]
+}
+
+// quoteStandard returns SQL standard string literal, single quotes are doubled.
+func quoteStandard(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// existingRefsInQuery returns query selecting stored refs with IN list of placeholders.
+func existingRefsInQuery(d Dialect, refs []string) (string, []interface{}) {
+	args := make([]interface{}, 0, len(refs))
+	for _, ref := range refs {
+		args = append(args, ref)
+	}
+	return fmt.Sprintf("select event_ref from event where event_ref in (%s)", Placeholders(d, 1, len(refs))), args
+}
+
+// Postgres is dialect of PostgreSQL database.
+type Postgres struct{}
+
+// Name is the name of database driver.
+func (Postgres) Name() string {
+	return "postgres"
+}
+
+// Placeholder returns numbered placeholder like $1.
+func (Postgres) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// UnixToDate will format go time to timestamp - thus will allow us to use epoch time
+// and don't rely on client and server timezones.
+func (Postgres) UnixToDate(epoch int64) string {
+	return fmt.Sprintf("to_timestamp(cast(%d as bigint))::date", epoch)
+}
+
+// Timestamp returns timestamp literal of time wall clock.
+func (Postgres) Timestamp(t time.Time) string {
+	return fmt.Sprintf("cast('%s' as timestamp)", t.Format(timestampLayout))
+}
+
+// Schema returns DDL statements creating event table.
+func (Postgres) Schema() []string {
+	return loadSchema("postgres.sql")
+}
+
+// Quote returns standard string literal, backslashes are not special with standard_conforming_strings.
+func (Postgres) Quote(s string) string {
+	return quoteStandard(s)
+}
+
+// Explain returns EXPLAIN statement, plan is estimated without executing the query.
+func (Postgres) Explain(query string) string {
+	return "explain " + query
+}
+
+// ExistingRefsQuery passes all the refs as a single array argument.
+func (Postgres) ExistingRefsQuery(refs []string) (string, []interface{}) {
+	return "select event_ref from event where event_ref = any($1)", []interface{}{pq.Array(refs)}
+}
+
+// MySQL is dialect of MySQL database.
+type MySQL struct{}
+
+// Name is the name of database driver.
+func (MySQL) Name() string {
+	return "mysql"
+}
+
+// Placeholder returns question mark placeholder.
+func (MySQL) Placeholder(int) string {
+	return "?"
+}
+
+// UnixToDate will convert epoch time to date on the server side.
+func (MySQL) UnixToDate(epoch int64) string {
+	return fmt.Sprintf("date(from_unixtime(%d))", epoch)
+}
+
+// Timestamp returns timestamp literal of time wall clock.
+func (MySQL) Timestamp(t time.Time) string {
+	return fmt.Sprintf("timestamp('%s')", t.Format(timestampLayout))
+}
+
+// Schema returns DDL statements creating event table.
+func (MySQL) Schema() []string {
+	return loadSchema("mysql.sql")
+}
+
+// Quote returns string literal with quotes doubled and backslashes escaped, as backslash is escape character in MySQL.
+func (MySQL) Quote(s string) string {
+	return quoteStandard(strings.ReplaceAll(s, `\`, `\\`))
+}
+
+// Explain returns EXPLAIN statement, inserts are explained since MySQL 5.6.
+func (MySQL) Explain(query string) string {
+	return "explain " + query
+}
+
+// ExistingRefsQuery passes refs as IN list.
+func (d MySQL) ExistingRefsQuery(refs []string) (string, []interface{}) {
+	return existingRefsInQuery(d, refs)
+}
+
+// SQLite is dialect of SQLite database.
+type SQLite struct{}
+
+// Name is the name of database driver.
+func (SQLite) Name() string {
+	return "sqlite3"
+}
+
+// Placeholder returns question mark placeholder.
+func (SQLite) Placeholder(int) string {
+	return "?"
+}
+
+// UnixToDate will convert epoch time to date on the database side.
+func (SQLite) UnixToDate(epoch int64) string {
+	return fmt.Sprintf("date(%d, 'unixepoch')", epoch)
+}
+
+// Timestamp returns timestamp literal of time wall clock.
+func (SQLite) Timestamp(t time.Time) string {
+	return fmt.Sprintf("'%s'", t.Format(timestampLayout))
+}
+
+// Schema returns DDL statements creating event table.
+func (SQLite) Schema() []string {
+	return loadSchema("sqlite.sql")
+}
+
+// Quote returns standard string literal.
+func (SQLite) Quote(s string) string {
+	return quoteStandard(s)
+}
+
+// Explain returns EXPLAIN QUERY PLAN statement, plain EXPLAIN prints virtual machine bytecode instead.
+func (SQLite) Explain(query string) string {
+	return "explain query plan " + query
+}
+
+// ExistingRefsQuery passes refs as IN list.
+func (d SQLite) ExistingRefsQuery(refs []string) (string, []interface{}) {
+	return existingRefsInQuery(d, refs)
+}
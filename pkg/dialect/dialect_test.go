@@ -0,0 +1,70 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/xo/dburl"
+)
+
+func TestUnsupportedDialect(t *testing.T) {
+	if _, err := ByName("oracle"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected unsupported database error, got %v", err)
+	}
+
+	url, err := dburl.Parse("sqlserver://localhost/events")
+	if err != nil {
+		t.Fatalf("unable to parse database URL : %v", err)
+	}
+	if _, err := ForURL(url); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected unsupported database error, got %v", err)
+	}
+	if _, _, err := Open(context.Background(), url); !errors.Is(err, ErrUnsupported) || errors.Is(err, ErrDBConnection) {
+		t.Errorf("expected unsupported database error, got %v", err)
+	}
+}
+
+func TestOpenUnreachableDatabase(t *testing.T) {
+	// port of closed listener refuses connections
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen : %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	url, err := dburl.Parse("postgres://user:password@" + addr + "/events?sslmode=disable")
+	if err != nil {
+		t.Fatalf("unable to parse database URL : %v", err)
+	}
+	if _, _, err := Open(context.Background(), url); !errors.Is(err, ErrDBConnection) {
+		t.Errorf("expected database connection error, got %v", err)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		input    string
+		expected string
+	}{
+		{dialect: Postgres{}, input: "Kyiv", expected: `'Kyiv'`},
+		{dialect: Postgres{}, input: "O'Brien", expected: `'O''Brien'`},
+		{dialect: Postgres{}, input: `C:\calls`, expected: `'C:\calls'`},
+		{dialect: MySQL{}, input: "O'Brien", expected: `'O''Brien'`},
+		{dialect: MySQL{}, input: `C:\calls`, expected: `'C:\\calls'`},
+		// escaped backslash must not escape the quote after it
+		{dialect: MySQL{}, input: `\'`, expected: `'\\'''`},
+		{dialect: SQLite{}, input: "O'Brien", expected: `'O''Brien'`},
+		{dialect: SQLite{}, input: `C:\calls`, expected: `'C:\calls'`},
+		{dialect: SQLite{}, input: "", expected: `''`},
+	}
+
+	for _, test := range tests {
+		if actual := test.dialect.Quote(test.input); actual != test.expected {
+			t.Errorf("%s : expected %s of %s, got %s", test.dialect.Name(), test.expected, test.input, actual)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+-- mysql doesn't support "if not exists" for indexes, so all of them are declared within the table
+create table if not exists event
+(
+    event_source     varchar(64)  not null, -- unique identifier of client
+    event_ref        varchar(64)  not null, -- unique identifier of event
+    event_type       integer      not null,
+    event_date       datetime     not null,
+    calling_number   BIGINT       not null,
+    called_number    BIGINT       not null,
+    location         varchar(255) not null,
+    duration_seconds BIGINT       not null,
+    attr_1           text,
+    attr_2           text,
+    attr_3           text,
+    attr_4           text,
+    attr_5           text,
+    attr_6           text,
+    attr_7           text,
+    attr_8           text,
+    PRIMARY KEY (event_source, event_ref),
+    INDEX event_called_number_index (called_number),
+    INDEX event_calling_number_index (calling_number),
+    INDEX event_event_date_index (event_date),
+    UNIQUE INDEX event_event_ref_uindex (event_ref),
+    INDEX event_event_type_index (event_type),
+    INDEX event_location_index (location)
+);
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AttributeStrategy generates value of a single configurable attribute.
+type AttributeStrategy func() string
+
+// AttributeStrategies are generation strategies of Attr1-Attr8 in order, all of them are free text by default.
+var AttributeStrategies = [8]AttributeStrategy{
+	RandomString,
+	RandomString,
+	RandomString,
+	RandomString,
+	RandomString,
+	RandomString,
+	RandomString,
+	RandomString,
+}
+
+// RandomAttributes returns values for Attr1-Attr8 generated by configured strategies.
+func RandomAttributes() [8]string {
+	var attrs [8]string
+	for i, strategy := range AttributeStrategies {
+		attrs[i] = strategy()
+	}
+	return attrs
+}
+
+// NumericCode returns strategy generating numeric codes of provided length.
+func NumericCode(length int) AttributeStrategy {
+	return func() string {
+		code := make([]byte, length)
+		for i := range code {
+			code[i] = byte('0' + randIntn(10))
+		}
+		return string(code)
+	}
+}
+
+// poolMaxRepeats is number of repeated values in a row after which strategy is considered exhausted.
+const poolMaxRepeats = 1000
+
+// Pool returns strategy drawing values from a fixed pool of size distinct values produced by strategy.
+// Pool is filled on the first call, so it follows the seed of random generator.
+// Pool is smaller than size if strategy produces fewer distinct values.
+func Pool(strategy AttributeStrategy, size int) AttributeStrategy {
+	var pool []string
+	return func() string {
+		if pool == nil {
+			pool = make([]string, 0, size)
+			seen := make(map[string]struct{}, size)
+			for repeats := 0; len(pool) < size && repeats < poolMaxRepeats; {
+				value := strategy()
+				if _, ok := seen[value]; ok {
+					repeats++
+					continue
+				}
+				seen[value] = struct{}{}
+				pool = append(pool, value)
+				repeats = 0
+			}
+		}
+		return pool[randIntn(len(pool))]
+	}
+}
+
+// ParseCardinality parses attributes cardinality like 6:5,7:50 to number of distinct values by attribute number from 1 to 8.
+func ParseCardinality(s string) (map[int]int, error) {
+	cardinality := map[int]int{}
+	for _, part := range strings.Split(s, ",") {
+		attr, size, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, invalidArgs("invalid attribute cardinality '%s', <attribute>:<distinct values> expected", part)
+		}
+
+		n, err := strconv.Atoi(attr)
+		if err != nil || n < 1 || n > len(AttributeStrategies) {
+			return nil, invalidArgs("invalid attribute '%s', number from 1 to %d expected", attr, len(AttributeStrategies))
+		}
+		if _, ok := cardinality[n]; ok {
+			return nil, invalidArgs("duplicated attribute %d", n)
+		}
+
+		values, err := strconv.Atoi(size)
+		if err != nil || values < 1 {
+			return nil, invalidArgs("invalid cardinality '%s' of attribute %d, positive number expected", size, n)
+		}
+		cardinality[n] = values
+	}
+	return cardinality, nil
+}
+
+// Nullable returns strategy producing empty value with provided probability and value of strategy otherwise,
+// empty attributes stand for the missing ones.
+func Nullable(strategy AttributeStrategy, prob float64) AttributeStrategy {
+	return func() string {
+		if randFloat64() < prob {
+			return ""
+		}
+		return strategy()
+	}
+}
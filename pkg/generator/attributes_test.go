@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// emptyRate returns fraction of empty values among n values of the strategy.
+func emptyRate(strategy AttributeStrategy, n int) float64 {
+	empty := 0
+	for i := 0; i < n; i++ {
+		if strategy() == "" {
+			empty++
+		}
+	}
+	return float64(empty) / float64(n)
+}
+
+func TestNullable(t *testing.T) {
+	const samples = 100000
+	value := func() string { return "value" }
+
+	for _, prob := range []float64{0, 0.05, 0.3, 0.5, 1} {
+		rate := emptyRate(Nullable(value, prob), samples)
+		// 5 standard deviations of binomial rate
+		tolerance := 5 * math.Sqrt(prob*(1-prob)/samples)
+		if math.Abs(rate-prob) > tolerance {
+			t.Errorf("probability %v : expected empty rate within %v, got %v", prob, tolerance, rate)
+		}
+	}
+}
+
+func TestNullableAttributesOfEvents(t *testing.T) {
+	defer func(strategies [8]AttributeStrategy) { AttributeStrategies = strategies }(AttributeStrategies)
+	for i, strategy := range AttributeStrategies {
+		AttributeStrategies[i] = Nullable(strategy, 0.2)
+	}
+
+	const events = 20000
+	empty := make([]int, len(AttributeStrategies))
+	for i := 0; i < events; i++ {
+		for j, attr := range RandomEvent(Options{}).Attributes() {
+			if attr == "" {
+				empty[j]++
+			}
+		}
+	}
+
+	// every attribute is nulled independently
+	tolerance := 5 * math.Sqrt(0.2*0.8/events)
+	for j, n := range empty {
+		if rate := float64(n) / events; math.Abs(rate-0.2) > tolerance {
+			t.Errorf("attribute %d : expected empty rate 0.2±%.3f, got %v", j+1, tolerance, rate)
+		}
+	}
+}
+
+func TestPoolCardinality(t *testing.T) {
+	defer func(strategies [8]AttributeStrategy) { AttributeStrategies = strategies }(AttributeStrategies)
+	cardinality, err := ParseCardinality("6:5,7:50")
+	if err != nil {
+		t.Fatalf("unable to parse cardinality : %v", err)
+	}
+	for attr, size := range cardinality {
+		AttributeStrategies[attr-1] = Pool(AttributeStrategies[attr-1], size)
+	}
+
+	distinct := make([]map[string]struct{}, len(AttributeStrategies))
+	for i := range distinct {
+		distinct[i] = map[string]struct{}{}
+	}
+	for i := 0; i < 10000; i++ {
+		for j, attr := range RandomEvent(Options{}).Attributes() {
+			distinct[j][attr] = struct{}{}
+		}
+	}
+
+	// every value of the pool is drawn out of 10000 events
+	if n := len(distinct[5]); n != 5 {
+		t.Errorf("expected 5 distinct values of attribute 6, got %d", n)
+	}
+	if n := len(distinct[6]); n != 50 {
+		t.Errorf("expected 50 distinct values of attribute 7, got %d", n)
+	}
+	// not constrained attributes stay random
+	if n := len(distinct[0]); n < 9000 {
+		t.Errorf("expected high cardinality of attribute 1, got %d distinct values", n)
+	}
+}
+
+func TestPoolOfLowCardinalityStrategy(t *testing.T) {
+	// pool is filled with distinct values even if strategy repeats them
+	strategy := Pool(NumericCode(1), 10)
+	distinct := map[string]struct{}{}
+	for i := 0; i < 1000; i++ {
+		distinct[strategy()] = struct{}{}
+	}
+	if len(distinct) != 10 {
+		t.Errorf("expected 10 distinct values, got %d", len(distinct))
+	}
+}
+
+func TestPoolLargerThanStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy AttributeStrategy
+		expected int
+	}{
+		{name: "digits", strategy: NumericCode(1), expected: 10},
+		{name: "constant", strategy: func() string { return "x" }, expected: 1},
+	}
+
+	for _, test := range tests {
+		// pool is filled with all the values strategy produces instead of looping forever
+		strategy := Pool(test.strategy, 100)
+		distinct := map[string]struct{}{}
+		for i := 0; i < 10000; i++ {
+			distinct[strategy()] = struct{}{}
+		}
+		if len(distinct) != test.expected {
+			t.Errorf("%s : expected %d distinct values, got %d", test.name, test.expected, len(distinct))
+		}
+	}
+}
+
+func TestParseCardinality(t *testing.T) {
+	cardinality, err := ParseCardinality(" 6:5, 7:50")
+	if err != nil {
+		t.Fatalf("unable to parse cardinality : %v", err)
+	}
+	if len(cardinality) != 2 || cardinality[6] != 5 || cardinality[7] != 50 {
+		t.Errorf("expected 6:5 and 7:50, got %v", cardinality)
+	}
+
+	for _, input := range []string{"", "6", "0:5", "9:5", "6:5,6:7", "6:0", "6:few"} {
+		if _, err := ParseCardinality(input); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("expected '%s' to be rejected with invalid arguments error, got %v", input, err)
+		}
+	}
+}
+
+// assertAttribute fails the test unless value is of length within [min, max] and consists of alphabet characters.
+func assertAttribute(t *testing.T, n int, value string, min, max int, alphabet string) {
+	t.Helper()
+	if len(value) < min || len(value) > max {
+		t.Fatalf("attribute %d : expected length within [%d, %d], got %q", n, min, max, value)
+	}
+	if i := strings.IndexFunc(value, func(r rune) bool { return !strings.ContainsRune(alphabet, r) }); i >= 0 {
+		t.Fatalf("attribute %d : expected characters of %q, got %q", n, alphabet, value)
+	}
+}
+
+func TestRandomAttributesFreeTextByDefault(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		for j, attr := range RandomAttributes() {
+			assertAttribute(t, j+1, attr, 1, 40, letters)
+		}
+	}
+}
+
+func TestRandomAttributesFollowStrategies(t *testing.T) {
+	defer func(strategies [8]AttributeStrategy) { AttributeStrategies = strategies }(AttributeStrategies)
+	AttributeStrategies[0] = NumericCode(6)
+	AttributeStrategies[5] = func() string { return "segment" }
+	AttributeStrategies[7] = Codes(3, "XYZ")
+
+	for i := 0; i < 1000; i++ {
+		attrs := RandomAttributes()
+		assertAttribute(t, 1, attrs[0], 6, 6, "0123456789")
+		if attrs[5] != "segment" {
+			t.Fatalf("attribute 6 : expected segment, got %q", attrs[5])
+		}
+		assertAttribute(t, 8, attrs[7], 3, 3, "XYZ")
+		// the rest keep free text
+		for _, j := range []int{1, 2, 3, 4, 6} {
+			assertAttribute(t, j+1, attrs[j], 1, 40, letters)
+		}
+	}
+}
+
+func TestNumericCode(t *testing.T) {
+	for _, length := range []int{0, 1, 12} {
+		strategy := NumericCode(length)
+		for i := 0; i < 100; i++ {
+			assertAttribute(t, 1, strategy(), length, length, "0123456789")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"strconv"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// CDRHeader is header of call detail records, columns are:
+//
+//  1. RECORD_TYPE - event type number
+//  2. RECORD_ID - event ref
+//  3. A_NUMBER - calling number
+//  4. B_NUMBER - called number
+//  5. START_TIME - event date as YYYYMMDDhhmmss in the event time zone
+//  6. DURATION - duration in seconds
+//  7. LOCATION - location
+//  8. SOURCE_ID - event source
+//  9. ATTR_1 - ATTR_8 - attributes in order
+//
+// Records are followed by a single column trailer +EOF+<number of records>.
+var CDRHeader = []string{
+	"RECORD_TYPE", "RECORD_ID", "A_NUMBER", "B_NUMBER", "START_TIME", "DURATION", "LOCATION", "SOURCE_ID",
+	"ATTR_1", "ATTR_2", "ATTR_3", "ATTR_4", "ATTR_5", "ATTR_6", "ATTR_7", "ATTR_8",
+}
+
+// cdrTimeLayout is layout of CDR start time.
+const cdrTimeLayout = "20060102150405"
+
+// cdrTrailerPrefix starts trailer record of CDR file.
+const cdrTrailerPrefix = "+EOF+"
+
+// CDRRecord returns call detail record of event with columns ordered as CDRHeader.
+func CDRRecord(e *model.Event) []string {
+	record := []string{
+		strconv.Itoa(int(e.EventType)),
+		e.EventRef,
+		strconv.Itoa(e.CallingNumber),
+		strconv.Itoa(e.CalledNumber),
+		e.EventDate.Format(cdrTimeLayout),
+		strconv.Itoa(e.DurationSeconds),
+		e.Location,
+		strconv.Itoa(e.EventSource),
+	}
+	attrs := e.Attributes()
+	return append(record, attrs[:]...)
+}
+
+// CDRTrailer returns trailer record of CDR file with provided number of records.
+func CDRTrailer(count int) []string {
+	return []string{cdrTrailerPrefix + strconv.Itoa(count)}
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestCDRRecordColumnOrder(t *testing.T) {
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	e := &model.Event{
+		EventSource:     7,
+		EventRef:        "0b6a6c4e-3f0e-4e8c-9a57-1f2b3c4d5e6f",
+		EventType:       model.EventTypeSMS,
+		EventDate:       time.Date(2022, 3, 14, 15, 9, 26, 535, zone),
+		CallingNumber:   79001234567,
+		CalledNumber:    79007654321,
+		Location:        "ABC",
+		DurationSeconds: 12,
+	}
+	e.SetAttributes([8]string{"a1", "", "a3", "", "", "", "", "a8"})
+
+	expected := []string{"2", "0b6a6c4e-3f0e-4e8c-9a57-1f2b3c4d5e6f", "79001234567", "79007654321", "20220314150926", "12", "ABC", "7",
+		"a1", "", "a3", "", "", "", "", "a8"}
+	record := CDRRecord(e)
+	if len(record) != len(CDRHeader) {
+		t.Fatalf("expected %d columns of header, got %d", len(CDRHeader), len(record))
+	}
+	for i := range expected {
+		if record[i] != expected[i] {
+			t.Errorf("column %d %s : expected '%s', got '%s'", i+1, CDRHeader[i], expected[i], record[i])
+		}
+	}
+}
+
+func TestCDRTrailerCount(t *testing.T) {
+	for _, n := range []int{0, 1, 123} {
+		events := make(model.Events, 0, n)
+		for i := 0; i < n; i++ {
+			events = append(events, RandomEvent(Options{}))
+		}
+
+		var buf bytes.Buffer
+		s, err := NewWriterSink(&buf, FormatCDR, true)
+		if err != nil {
+			t.Fatalf("unable to create sink : %v", err)
+		}
+		writeEvents(t, s, events)
+
+		r := csv.NewReader(&buf)
+		// trailer has a single column
+		r.FieldsPerRecord = -1
+		records, err := r.ReadAll()
+		if err != nil {
+			t.Fatalf("unable to read cdr : %v", err)
+		}
+		if len(records) != n+2 {
+			t.Fatalf("expected header, %d records and trailer, got %d lines", n, len(records))
+		}
+
+		if header := strings.Join(records[0], ","); header != strings.Join(CDRHeader, ",") {
+			t.Errorf("expected header %v, got %v", CDRHeader, records[0])
+		}
+		for i, e := range events {
+			if record := records[i+1]; strings.Join(record, ",") != strings.Join(CDRRecord(e), ",") {
+				t.Errorf("record %d : expected %v, got %v", i, CDRRecord(e), record)
+			}
+		}
+
+		trailer := records[len(records)-1]
+		if len(trailer) != 1 || trailer[0] != "+EOF+"+strconv.Itoa(n) {
+			t.Errorf("expected trailer +EOF+%d, got %v", n, trailer)
+		}
+	}
+}
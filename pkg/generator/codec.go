@@ -0,0 +1,266 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// supported serialization formats.
+const (
+	// FormatJSON is a single json array of events.
+	FormatJSON = "json"
+	// FormatNDJSON is newline delimited json, one event per line.
+	FormatNDJSON = "ndjson"
+	// FormatCSV is CSV with header.
+	FormatCSV = "csv"
+	// FormatCDR is call detail records CSV layout of CDRHeader with +EOF+<count> trailer.
+	FormatCDR = "cdr"
+	// FormatAuto makes DetectFormat detect format by file extension.
+	FormatAuto = "auto"
+)
+
+// MaxLineSize is the longest ndjson line StreamNDJSON is able to read.
+// Generated event with all 8 attributes filled is about 0.5KB, but external files could have multi-kilobyte attributes.
+const MaxLineSize = 64 * 1024 * 1024
+
+// Warnings is destination of messages about skipped malformed input, it's standard output by default.
+var Warnings io.Writer = os.Stdout
+
+// DetectFormat returns format of events stored in provided file, format is detected by extension if it's FormatAuto.
+// Explicit format must be one of accepted ones, standard input is expected to be ndjson as it's streamed line by line.
+func DetectFormat(format, filename string, accepted ...string) (string, error) {
+	if format == FormatAuto {
+		if filename == "-" {
+			format = FormatNDJSON
+		} else {
+			format = FormatFromExt(filename)
+		}
+	}
+
+	for _, f := range accepted {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", invalidArgs("format '%s' of '%s', one of %s expected", format, filename, strings.Join(accepted, ", "))
+}
+
+// FormatFromExt detects serialization format by file extension, json is used for unknown extensions.
+func FormatFromExt(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	case ".csv":
+		return FormatCSV
+	case ".cdr":
+		return FormatCDR
+	}
+	return FormatJSON
+}
+
+// ReadEvents will read all the events stored in provided format.
+func ReadEvents(r io.Reader, format string) (model.Events, error) {
+	switch format {
+	case FormatJSON:
+		return ReadJSON(r)
+	case FormatNDJSON:
+		return ReadNDJSON(r)
+	case FormatCSV:
+		return ReadCSV(r)
+	}
+	return nil, invalidArgs("unknown format '%s'", format)
+}
+
+// StreamEvents will call fn for every event stored in provided format, events are decoded one by one,
+// so memory usage doesn't depend on the input size.
+// Returns number of skipped malformed ndjson lines, malformed json and csv fail the whole input.
+func StreamEvents(r io.Reader, format string, fn func(e *model.Event) error) (int, error) {
+	switch format {
+	case FormatJSON:
+		return 0, StreamJSON(r, fn)
+	case FormatNDJSON:
+		return StreamNDJSON(r, fn)
+	case FormatCSV:
+		return 0, StreamCSV(r, fn)
+	}
+	return 0, invalidArgs("unknown format '%s'", format)
+}
+
+// WriteJSON will write events to provided writer as a single json array, nil events are written as empty array.
+func WriteJSON(w io.Writer, events model.Events) error {
+	if events == nil {
+		events = model.Events{}
+	}
+	content, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("unable to marshall events : %w", err)
+	}
+
+	_, err = w.Write(content)
+	return err
+}
+
+// ReadJSON will read events stored as a single json array.
+func ReadJSON(r io.Reader) (model.Events, error) {
+	events := model.Events{}
+	err := StreamJSON(r, func(e *model.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// StreamJSON will call fn for every event of a single json array decoding events one by one,
+// so memory usage doesn't depend on the array size.
+func StreamJSON(r io.Reader, fn func(e *model.Event) error) error {
+	dec := json.NewDecoder(r)
+
+	token, err := dec.Token()
+	if err != nil {
+		return badInput("unable to read array start : %v", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return badInput("json array expected, got %v", token)
+	}
+
+	for i := 0; dec.More(); i++ {
+		var e model.Event
+		if err := dec.Decode(&e); err != nil {
+			return badInput("unable to unmarshall event %d : %v", i, err)
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("unable to read array end : %w", err)
+	}
+	return nil
+}
+
+// WriteNDJSON will write events to provided writer as newline delimited json, one event per line.
+func WriteNDJSON(w io.Writer, events model.Events) error {
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("unable to marshall event : %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadNDJSON will read events stored as newline delimited json, malformed lines are skipped like by StreamNDJSON.
+func ReadNDJSON(r io.Reader) (model.Events, error) {
+	var events model.Events
+	_, err := StreamNDJSON(r, func(e *model.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// StreamNDJSON will call fn for every line of newline delimited json, blank lines are ignored.
+// Malformed lines are reported to Warnings and skipped, returns number of skipped lines.
+func StreamNDJSON(r io.Reader, fn func(e *model.Event) error) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxLineSize)
+
+	skipped := 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var e model.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			fmt.Fprintf(Warnings, "skipping malformed line %d : %v\n", line, err)
+			skipped++
+			continue
+		}
+
+		if err := fn(&e); err != nil {
+			return skipped, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return skipped, badInput("unable to read line : %v", err)
+	}
+	return skipped, nil
+}
+
+// WriteCSV will write events to provided writer as CSV, header is written only if requested.
+func WriteCSV(w io.Writer, events model.Events, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(model.CSVHeader); err != nil {
+			return fmt.Errorf("unable to write csv header : %w", err)
+		}
+	}
+	for _, e := range events {
+		if err := cw.Write(e.CSVRecord()); err != nil {
+			return fmt.Errorf("unable to write event : %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV will read events stored as CSV with header.
+func ReadCSV(r io.Reader) (model.Events, error) {
+	var events model.Events
+	err := StreamCSV(r, func(e *model.Event) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// StreamCSV will call fn for every event of CSV with header.
+func StreamCSV(r io.Reader, fn func(e *model.Event) error) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	if _, err := cr.Read(); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return badInput("unable to read csv header : %v", err)
+	}
+
+	for i := 0; ; i++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return badInput("unable to read csv record : %v", err)
+		}
+
+		e, err := model.EventFromCSVRecord(record)
+		if err != nil {
+			return badInput("unable to parse event %d : %v", i, err)
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+}
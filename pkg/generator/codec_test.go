@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// codecEvents returns random events and events with values which have to be escaped.
+func codecEvents() model.Events {
+	kyiv := time.FixedZone("EET", 2*60*60)
+	events := model.Events{
+		NewEvent(WithRef("ref-1"), WithDate(time.Date(2022, 3, 14, 15, 9, 26, 535897932, kyiv)), WithLocation("a,b"),
+			WithAttributes([8]string{"quote \"x\"", "line\nbreak", "comma, and ; semicolon", "юнікод", "", "\ttab", "\\", "{}"})),
+		NewEvent(WithRef("ref-2"), WithDuration(0), WithAttributes([8]string{})),
+	}
+	for i := 0; i < 100; i++ {
+		events = append(events, RandomEvent(Options{}))
+	}
+	return events
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	events := codecEvents()
+
+	tests := []struct {
+		format string
+		write  func(buf *bytes.Buffer) error
+		read   func(buf *bytes.Buffer) (model.Events, error)
+	}{
+		{
+			format: FormatJSON,
+			write:  func(buf *bytes.Buffer) error { return WriteJSON(buf, events) },
+			read:   func(buf *bytes.Buffer) (model.Events, error) { return ReadJSON(buf) },
+		},
+		{
+			format: FormatNDJSON,
+			write:  func(buf *bytes.Buffer) error { return WriteNDJSON(buf, events) },
+			read:   func(buf *bytes.Buffer) (model.Events, error) { return ReadNDJSON(buf) },
+		},
+		{
+			format: FormatCSV,
+			write:  func(buf *bytes.Buffer) error { return WriteCSV(buf, events, true) },
+			read:   func(buf *bytes.Buffer) (model.Events, error) { return ReadCSV(buf) },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := test.write(&buf); err != nil {
+				t.Fatalf("unable to write events : %v", err)
+			}
+			content := buf.Bytes()
+
+			read, err := test.read(bytes.NewBuffer(content))
+			if err != nil {
+				t.Fatalf("unable to read events : %v", err)
+			}
+			assertEvents(t, events, read)
+
+			// generic readers pick the same codec by format
+			read, err = ReadEvents(bytes.NewReader(content), test.format)
+			if err != nil {
+				t.Fatalf("unable to read events as %s : %v", test.format, err)
+			}
+			assertEvents(t, events, read)
+
+			var streamed model.Events
+			if _, err := StreamEvents(bytes.NewReader(content), test.format, func(e *model.Event) error {
+				streamed = append(streamed, e)
+				return nil
+			}); err != nil {
+				t.Fatalf("unable to stream events as %s : %v", test.format, err)
+			}
+			assertEvents(t, events, streamed)
+		})
+	}
+}
+
+func TestCodecRoundTripOfNoEvents(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, nil); err != nil {
+		t.Fatalf("unable to write events : %v", err)
+	}
+	events, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("unable to read events : %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events, got %d", len(events))
+	}
+
+	buf.Reset()
+	if err := WriteNDJSON(&buf, nil); err != nil {
+		t.Fatalf("unable to write events : %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written, got %q", buf.String())
+	}
+}
+
+func TestReadCamelCaseKeys(t *testing.T) {
+	withMillisDurations(t, true)
+	events := model.Events{NewEvent(WithRef("ref-1")), NewEvent(WithRef("ref-2"))}
+
+	p, err := model.NewProjection(append(model.FieldNames(), "duration_millis"))
+	if err != nil {
+		t.Fatalf("unable to create projection : %v", err)
+	}
+	p = p.CamelCase()
+
+	var lines [][]byte
+	for _, e := range events {
+		line, err := p.JSON(e)
+		if err != nil {
+			t.Fatalf("unable to marshal event : %v", err)
+		}
+		lines = append(lines, line)
+	}
+
+	// camelCase written by generator -json-case camel is read without any configuration
+	inputs := map[string][]byte{
+		FormatNDJSON: append(bytes.Join(lines, []byte("\n")), '\n'),
+		FormatJSON:   append(append([]byte("["), bytes.Join(lines, []byte(","))...), ']'),
+	}
+	for format, content := range inputs {
+		read, err := ReadEvents(bytes.NewReader(content), format)
+		if err != nil {
+			t.Fatalf("%s : unable to read events : %v", format, err)
+		}
+		assertEvents(t, events, read)
+	}
+}
+
+func TestStreamNDJSONSkipsMalformedLines(t *testing.T) {
+	defer func(w io.Writer) { Warnings = w }(Warnings)
+	var warnings bytes.Buffer
+	Warnings = &warnings
+
+	content := "{\"event_ref\":\"ref-1\"}\n{\"event_type\":\n\n{\"event_ref\":\"ref-2\"}\nnot json\n"
+	var refs []string
+	skipped, err := StreamNDJSON(strings.NewReader(content), func(e *model.Event) error {
+		refs = append(refs, e.EventRef)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to stream events : %v", err)
+	}
+	if skipped != 2 {
+		t.Errorf("expected 2 skipped lines, got %d", skipped)
+	}
+	if len(refs) != 2 || refs[0] != "ref-1" || refs[1] != "ref-2" {
+		t.Errorf("expected events ref-1 and ref-2, got %v", refs)
+	}
+	// lines are numbered from 1 including blank ones
+	for _, line := range []string{"skipping malformed line 2 ", "skipping malformed line 5 "} {
+		if !strings.Contains(warnings.String(), line) {
+			t.Errorf("expected %q in warnings, got:\n%s", line, warnings.String())
+		}
+	}
+
+	// generic streaming skips them the same way
+	if skipped, err := StreamEvents(strings.NewReader(content), FormatNDJSON, func(e *model.Event) error { return nil }); err != nil || skipped != 2 {
+		t.Errorf("expected 2 skipped lines, got %d and error %v", skipped, err)
+	}
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is generation parameters stored in YAML or JSON file, keys are named after generator flags.
+// Zero values are treated as not set, so defaults are kept.
+type Config struct {
+	// Count is number of events to generate or range like 1000-5000.
+	Count string `yaml:"count"`
+	// Output is output file.
+	Output string `yaml:"output"`
+
+	Seed              int64   `yaml:"seed"`
+	Format            string  `yaml:"format"`
+	Sink              string  `yaml:"sink"`
+	Stats             string  `yaml:"stats"`
+	Dist              string  `yaml:"dist"`
+	Since             string  `yaml:"since"`
+	Until             string  `yaml:"until"`
+	TZ                string  `yaml:"tz"`
+	PeakHours         string  `yaml:"peak-hours"`
+	PeakFactor        float64 `yaml:"peak-factor"`
+	TrafficShape      string  `yaml:"traffic-shape"`
+	RefVersion        int     `yaml:"ref-version"`
+	Fields            string  `yaml:"fields"`
+	JSONCase          string  `yaml:"json-case"`
+	AttrCardinality   string  `yaml:"attr-cardinality"`
+	NullProb          float64 `yaml:"null-prob"`
+	Durations         string  `yaml:"durations"`
+	CorrelateLocation bool    `yaml:"correlate-location"`
+	LocationLen       int     `yaml:"location-len"`
+	SourceMax         int64   `yaml:"source-max"`
+	NumberMax         int64   `yaml:"number-max"`
+	Sessions          float64 `yaml:"sessions"`
+	SessionParts      int     `yaml:"session-parts"`
+	FraudPrefixes     string  `yaml:"fraud-prefixes"`
+	FraudRate         float64 `yaml:"fraud-rate"`
+	Rate              float64 `yaml:"rate"`
+	MaxFileSize       string  `yaml:"max-file-size"`
+	BufferSize        string  `yaml:"buffer-size"`
+	LateRate          float64 `yaml:"late-rate"`
+	LateDelay         string  `yaml:"late-delay"`
+	Sort              bool    `yaml:"sort"`
+	Derive            string  `yaml:"derive"`
+	Pretty            bool    `yaml:"pretty"`
+}
+
+// LoadConfig will read config from YAML or JSON file, unknown keys are reported as errors.
+func LoadConfig(filename string) (*Config, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config : %w", err)
+	}
+
+	var c Config
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil {
+		return nil, badInput("unable to parse config %s : %v", filename, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Validate checks values which could be validated without the rest of parameters.
+func (c *Config) Validate() error {
+	switch c.Format {
+	case "", FormatJSON, FormatNDJSON, FormatCSV, FormatCDR:
+	default:
+		return invalidArgs("unknown format '%s'", c.Format)
+	}
+	switch c.TrafficShape {
+	case "", ShapeUniform, ShapeBursty, ShapeDiurnal:
+	default:
+		return invalidArgs("unknown traffic shape '%s'", c.TrafficShape)
+	}
+	if c.RefVersion != 0 && c.RefVersion != 4 && c.RefVersion != 7 {
+		return invalidArgs("unsupported ref version %d, 4 or 7 expected", c.RefVersion)
+	}
+	for name, p := range map[string]float64{"null-prob": c.NullProb, "sessions": c.Sessions, "fraud-rate": c.FraudRate, "late-rate": c.LateRate} {
+		if p < 0 || p > 1 {
+			return invalidArgs("%s %v, value from 0.0 to 1.0 expected", name, p)
+		}
+	}
+	return nil
+}
+
+// Values returns set parameters except count and output as strings keyed by flag names.
+func (c *Config) Values() map[string]string {
+	values := map[string]string{}
+
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("yaml")
+		if name == "count" || name == "output" || v.Field(i).IsZero() {
+			continue
+		}
+		values[name] = fmt.Sprint(v.Field(i).Interface())
+	}
+	return values
+}
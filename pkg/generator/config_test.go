@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfig writes config file with provided name and content.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("unable to write config : %v", err)
+	}
+	return filename
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name, content string
+	}{
+		{name: "config.yaml", content: "count: 1000-5000\noutput: events.ndjson\nformat: ndjson\nseed: 42\npeak-factor: 2.5\nsort: true\n"},
+		{name: "config.json", content: `{"count": "1000-5000", "output": "events.ndjson", "format": "ndjson", "seed": 42, "peak-factor": 2.5, "sort": true}`},
+	}
+
+	for _, test := range tests {
+		c, err := LoadConfig(writeConfig(t, test.name, test.content))
+		if err != nil {
+			t.Fatalf("%s : unable to load config : %v", test.name, err)
+		}
+		if c.Count != "1000-5000" || c.Output != "events.ndjson" {
+			t.Errorf("%s : expected count 1000-5000 and output events.ndjson, got %s and %s", test.name, c.Count, c.Output)
+		}
+
+		// count and output are arguments, not flags, and not set values keep flag defaults
+		expected := map[string]string{"format": "ndjson", "seed": "42", "peak-factor": "2.5", "sort": "true"}
+		values := c.Values()
+		if len(values) != len(expected) {
+			t.Errorf("%s : expected values %v, got %v", test.name, expected, values)
+		}
+		for name, value := range expected {
+			if values[name] != value {
+				t.Errorf("%s : expected %s '%s', got '%s'", test.name, name, value, values[name])
+			}
+		}
+	}
+}
+
+func TestLoadConfigRejectsUnknownKeys(t *testing.T) {
+	filename := writeConfig(t, "config.yaml", "format: ndjson\nformt: csv\n")
+	if _, err := LoadConfig(filename); !errors.Is(err, ErrBadInput) {
+		t.Errorf("expected bad input error, got %v", err)
+	}
+}
+
+func TestLoadConfigRejectsInvalidValues(t *testing.T) {
+	for _, content := range []string{"format: xml", "traffic-shape: spiky", "ref-version: 5", "null-prob: 1.5", "late-rate: -0.1"} {
+		if _, err := LoadConfig(writeConfig(t, "config.yaml", content)); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("'%s' : expected invalid arguments error, got %v", content, err)
+		}
+	}
+}
+
+func TestLoadMissingConfig(t *testing.T) {
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// kinds of corruption of invalid events, every kind fails model.Event.Validate.
+const (
+	// CorruptDuration sets negative duration.
+	CorruptDuration = "duration"
+	// CorruptRef sets event ref which is not UUID.
+	CorruptRef = "ref"
+	// CorruptType sets unknown event type.
+	CorruptType = "type"
+	// CorruptDate drops event date.
+	CorruptDate = "date"
+	// CorruptLocation drops location.
+	CorruptLocation = "location"
+	// CorruptAny picks one of the kinds above for every corrupted event.
+	CorruptAny = "any"
+)
+
+// corruptors change event so it fails validation, duration is set by builder as it's drawn after setters.
+var corruptors = map[string]func(e *model.Event){
+	CorruptRef:      func(e *model.Event) { e.EventRef = "not-a-uuid-" + RandomStringN(8) },
+	CorruptType:     func(e *model.Event) { e.EventType = model.EventType(100 + randIntn(100)) },
+	CorruptDate:     func(e *model.Event) { e.EventDate = time.Time{} },
+	CorruptLocation: func(e *model.Event) { e.Location = "" },
+}
+
+// corruptKinds are kinds CorruptAny picks from.
+var corruptKinds = []string{CorruptDuration, CorruptRef, CorruptType, CorruptDate, CorruptLocation}
+
+// WithCorruption makes event invalid in the way of provided kind with probability of rate,
+// so error handling of event consumers could be tested.
+// Option must be the last one, otherwise following options may fix the corrupted field.
+func WithCorruption(kind string, rate float64) (EventOption, error) {
+	if _, ok := corruptors[kind]; !ok && kind != CorruptDuration && kind != CorruptAny {
+		return nil, invalidArgs("unknown corruption kind '%s'", kind)
+	}
+	if rate < 0 || rate > 1 {
+		return nil, invalidArgs("corruption rate %v, value from 0.0 to 1.0 expected", rate)
+	}
+
+	return func(b *eventBuilder) {
+		if randFloat64() >= rate {
+			return
+		}
+
+		k := kind
+		if k == CorruptAny {
+			k = corruptKinds[randIntn(len(corruptKinds))]
+		}
+		if k == CorruptDuration {
+			d := -1 - randIntn(maxDuration)
+			b.duration = &d
+			return
+		}
+		b.setters = append(b.setters, corruptors[k])
+	}, nil
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// corruptProblems are validation problems of every corruption kind.
+var corruptProblems = map[string]string{
+	CorruptDuration: "negative duration",
+	CorruptRef:      "invalid event ref",
+	CorruptType:     "unknown event type",
+	CorruptDate:     "missing event date",
+	CorruptLocation: "missing location",
+}
+
+// corruptedKinds generates events corrupted with provided kind and rate,
+// returns numbers of invalid events by kinds of their problems.
+func corruptedKinds(t *testing.T, kind string, rate float64, n int) map[string]int {
+	t.Helper()
+	corruption, err := WithCorruption(kind, rate)
+	if err != nil {
+		t.Fatalf("unable to create corruption : %v", err)
+	}
+
+	kinds := map[string]int{}
+	for i := 0; i < n; i++ {
+		err := RandomEvent(Options{Events: []EventOption{corruption}}).Validate()
+		if err == nil {
+			continue
+		}
+		found := false
+		for k, problem := range corruptProblems {
+			if strings.Contains(err.Error(), problem) {
+				kinds[k]++
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("unexpected validation problem : %v", err)
+		}
+	}
+	return kinds
+}
+
+func TestCorruptionKinds(t *testing.T) {
+	const n, rate = 10000, 0.2
+	for _, kind := range corruptKinds {
+		kinds := corruptedKinds(t, kind, rate, n)
+		if len(kinds) != 1 {
+			t.Errorf("%s : expected only %s problems, got %v", kind, kind, kinds)
+		}
+		if actual := float64(kinds[kind]) / n; math.Abs(actual-rate) > 0.02 {
+			t.Errorf("%s : expected %.2f of events to be corrupted, got %.4f", kind, rate, actual)
+		}
+	}
+}
+
+func TestCorruptionAnyKind(t *testing.T) {
+	const n = 10000
+	kinds := corruptedKinds(t, CorruptAny, 1, n)
+
+	total := 0
+	for _, kind := range corruptKinds {
+		// every kind is picked equally likely
+		expected := 1 / float64(len(corruptKinds))
+		if actual := float64(kinds[kind]) / n; math.Abs(actual-expected) > 0.02 {
+			t.Errorf("%s : expected %.2f of events, got %.4f", kind, expected, actual)
+		}
+		total += kinds[kind]
+	}
+	if total != n {
+		t.Errorf("expected every event to have a single problem, got %d problems of %d events", total, n)
+	}
+}
+
+func TestCorruptionRateBounds(t *testing.T) {
+	if kinds := corruptedKinds(t, CorruptRef, 0, 1000); len(kinds) != 0 {
+		t.Errorf("expected no corrupted events, got %v", kinds)
+	}
+	if kinds := corruptedKinds(t, CorruptRef, 1, 1000); kinds[CorruptRef] != 1000 {
+		t.Errorf("expected every event to be corrupted, got %v", kinds)
+	}
+}
+
+func TestWithCorruptionRejectsInvalidArgs(t *testing.T) {
+	tests := []struct {
+		kind string
+		rate float64
+	}{
+		{kind: "number", rate: 0.1},
+		{kind: CorruptRef, rate: -0.1},
+		{kind: CorruptRef, rate: 1.1},
+	}
+	for _, test := range tests {
+		if _, err := WithCorruption(test.kind, test.rate); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("%s with rate %v : expected invalid arguments error, got %v", test.kind, test.rate, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// CountEvents will count events stored in provided format without decoding them into events,
+// input is streamed, so memory usage doesn't depend on its size.
+// Json array elements are only validated to be json values, ndjson counts non-blank lines.
+func CountEvents(r io.Reader, format string) (int, error) {
+	switch format {
+	case FormatJSON:
+		return countJSON(r)
+	case FormatNDJSON:
+		return countNDJSON(r)
+	case FormatCSV:
+		return countCSV(r)
+	}
+	return 0, invalidArgs("unknown format '%s'", format)
+}
+
+// countJSON will count elements of a single json array.
+func countJSON(r io.Reader) (int, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	token, err := dec.Token()
+	if err != nil {
+		return 0, badInput("unable to read array start : %v", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return 0, badInput("json array expected, got %v", token)
+	}
+
+	count := 0
+	for ; dec.More(); count++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return count, badInput("unable to read event %d : %v", count, err)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return count, badInput("unable to read array end : %v", err)
+	}
+	return count, nil
+}
+
+// countNDJSON will count non-blank lines.
+func countNDJSON(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), BufferSize)
+
+	count := 0
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return count, badInput("unable to read line %d : %v", count, err)
+	}
+	return count, nil
+}
+
+// countCSV will count records of CSV with header.
+func countCSV(r io.Reader) (int, error) {
+	cr := csv.NewReader(bufio.NewReader(r))
+	cr.ReuseRecord = true
+
+	if _, err := cr.Read(); err != nil {
+		if err == io.EOF {
+			return 0, nil
+		}
+		return 0, badInput("unable to read csv header : %v", err)
+	}
+
+	for count := 0; ; count++ {
+		if _, err := cr.Read(); err != nil {
+			if err == io.EOF {
+				return count, nil
+			}
+			return count, badInput("unable to read csv record : %v", err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// encodeEvents returns events written by sink of provided format.
+func encodeEvents(t *testing.T, format string, events model.Events) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	s, err := NewWriterSink(&buf, format, true)
+	if err != nil {
+		t.Fatalf("unable to create sink : %v", err)
+	}
+	writeEvents(t, s, events)
+	return buf.Bytes()
+}
+
+func TestCountEvents(t *testing.T) {
+	events := make(model.Events, 0, 100)
+	for i := 0; i < cap(events); i++ {
+		events = append(events, RandomEvent(Options{}))
+	}
+	// multiline attribute is quoted by csv and escaped by json, so it's still a single record
+	events[0].SetAttributes([8]string{"multi\nline", "with, comma"})
+
+	for _, format := range []string{FormatJSON, FormatNDJSON, FormatCSV} {
+		for _, n := range []int{0, 1, len(events)} {
+			count, err := CountEvents(bytes.NewReader(encodeEvents(t, format, events[:n])), format)
+			if err != nil {
+				t.Errorf("%s : unable to count %d events : %v", format, n, err)
+			} else if count != n {
+				t.Errorf("%s : expected %d events, got %d", format, n, count)
+			}
+		}
+	}
+}
+
+func TestCountEventsSkipsBlankLines(t *testing.T) {
+	content := "\n{\"event_ref\":\"ref-1\"}\n  \n{\"event_ref\":\"ref-2\"}\n\n"
+	count, err := CountEvents(strings.NewReader(content), FormatNDJSON)
+	if err != nil {
+		t.Fatalf("unable to count events : %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 events, got %d", count)
+	}
+}
+
+func TestCountEventsOfEmptyCSV(t *testing.T) {
+	count, err := CountEvents(strings.NewReader(""), FormatCSV)
+	if err != nil {
+		t.Fatalf("unable to count events : %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 events, got %d", count)
+	}
+}
+
+func TestCountEventsRejectsMalformedInput(t *testing.T) {
+	tests := []struct {
+		format, content string
+	}{
+		{format: FormatJSON, content: ""},
+		{format: FormatJSON, content: `{"event_ref":"ref-1"}`},
+		{format: FormatJSON, content: `[{"event_ref":"ref-1"},`},
+		{format: FormatJSON, content: `[{"event_ref":}]`},
+		{format: FormatCSV, content: "a,b\n1,2,3\n"},
+		{format: FormatCSV, content: "a,b\n\"1,2\n"},
+	}
+
+	for _, test := range tests {
+		if _, err := CountEvents(strings.NewReader(test.content), test.format); !errors.Is(err, ErrBadInput) {
+			t.Errorf("%s %q : expected bad input error, got %v", test.format, test.content, err)
+		}
+	}
+}
+
+func TestCountEventsRejectsUnknownFormat(t *testing.T) {
+	if _, err := CountEvents(strings.NewReader(""), "xml"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("expected invalid arguments error, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HourWeights are relative weights of every hour of day used by RandomDate, hours are uniform by default.
+var HourWeights = UniformHourWeights()
+
+// UniformHourWeights returns weights making every hour of day equally likely.
+func UniformHourWeights() [24]float64 {
+	var weights [24]float64
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+// PeakHourWeights returns weights making hours from `from` inclusively to `to` exclusively
+// `factor` times more likely than the rest of the day. Window may wrap around midnight, e.g. 22-6.
+func PeakHourWeights(from, to int, factor float64) ([24]float64, error) {
+	if from < 0 || from > 23 || to < 0 || to > 24 {
+		return [24]float64{}, invalidArgs("peak hours must be within 0-24, got %d-%d", from, to)
+	}
+	if factor <= 0 {
+		return [24]float64{}, invalidArgs("peak factor must be positive, got %v", factor)
+	}
+
+	weights := UniformHourWeights()
+	for h := from; h != to%24; h = (h + 1) % 24 {
+		weights[h] = factor
+	}
+	return weights, nil
+}
+
+// ParseHourWindow parses hours window in form of "9-21".
+func ParseHourWindow(s string) (from, to int, err error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, invalidArgs("invalid hours window '%s', expected <from>-<to>", s)
+	}
+
+	from, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, invalidArgs("invalid window start '%s' : %v", parts[0], err)
+	}
+	to, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, invalidArgs("invalid window end '%s' : %v", parts[1], err)
+	}
+	return from, to, nil
+}
+
+// randomHour returns hour of day picked according to HourWeights.
+func randomHour() int {
+	return weightedHour(&HourWeights)
+}
+
+// weightedHour returns hour of day picked according to provided weights.
+func weightedHour(weights *[24]float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	r := randFloat64() * total
+	for h, w := range weights {
+		if r < w {
+			return h
+		}
+		r -= w
+	}
+	return 23
+}
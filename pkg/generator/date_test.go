@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// hourHistogram returns share of every hour of day among n random dates.
+func hourHistogram(n int) [24]float64 {
+	var histogram [24]float64
+	for i := 0; i < n; i++ {
+		histogram[RandomDate().Hour()]++
+	}
+	for h := range histogram {
+		histogram[h] /= float64(n)
+	}
+	return histogram
+}
+
+func TestPeakHoursHistogram(t *testing.T) {
+	defer func(weights [24]float64) { HourWeights = weights }(HourWeights)
+	const samples = 200000
+
+	tests := []struct {
+		name     string
+		from, to int
+		factor   float64
+	}{
+		{name: "day peak", from: 9, to: 21, factor: 3},
+		{name: "night peak across midnight", from: 22, to: 6, factor: 5},
+		{name: "single hour", from: 12, to: 13, factor: 10},
+		{name: "uniform", from: 0, to: 24, factor: 2},
+	}
+
+	for _, test := range tests {
+		weights, err := PeakHourWeights(test.from, test.to, test.factor)
+		if err != nil {
+			t.Fatalf("%s : unable to build hour weights : %v", test.name, err)
+		}
+		HourWeights = weights
+
+		var total float64
+		for _, w := range weights {
+			total += w
+		}
+
+		histogram := hourHistogram(samples)
+		for h, share := range histogram {
+			expected := weights[h] / total
+			// 5 standard deviations of binomial share
+			tolerance := 5 * math.Sqrt(expected*(1-expected)/samples)
+			if math.Abs(share-expected) > tolerance {
+				t.Errorf("%s : expected %.4f±%.4f of hour %d, got %.4f", test.name, expected, tolerance, h, share)
+			}
+		}
+	}
+}
+
+func TestPeakHourWeights(t *testing.T) {
+	weights, err := PeakHourWeights(22, 2, 4)
+	if err != nil {
+		t.Fatalf("unable to build hour weights : %v", err)
+	}
+	for h, w := range weights {
+		expected := 1.0
+		if h >= 22 || h < 2 {
+			expected = 4
+		}
+		if w != expected {
+			t.Errorf("hour %d : expected weight %v, got %v", h, expected, w)
+		}
+	}
+
+	for _, window := range [][2]int{{-1, 5}, {24, 5}, {5, 25}, {5, -1}} {
+		if _, err := PeakHourWeights(window[0], window[1], 3); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("expected window %d-%d to be rejected, got %v", window[0], window[1], err)
+		}
+	}
+	for _, factor := range []float64{0, -2} {
+		if _, err := PeakHourWeights(9, 21, factor); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("expected factor %v to be rejected, got %v", factor, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package generator
+
+import "time"
+
+// DateGenerator generates dates of events, implementations shape how events are spread in time.
+type DateGenerator interface {
+	Next() time.Time
+}
+
+// uniformHours make every hour of day equally likely.
+var uniformHours = UniformHourWeights()
+
+// UniformDateGen generates dates with every hour of day equally likely between Since and Until,
+// dates are between 2010 and 2020 years if Since is zero.
+type UniformDateGen struct {
+	Since, Until time.Time
+}
+
+// Next returns random date.
+func (g UniformDateGen) Next() time.Time {
+	return randomDate(g.Since, g.Until, &uniformHours)
+}
+
+// PeakHoursDateGen generates dates between Since and Until with hour of day picked according to Weights,
+// e.g. created by PeakHourWeights. Dates are between 2010 and 2020 years if Since is zero.
+type PeakHoursDateGen struct {
+	Since, Until time.Time
+	Weights      [24]float64
+}
+
+// Next returns random date.
+func (g PeakHoursDateGen) Next() time.Time {
+	return randomDate(g.Since, g.Until, &g.Weights)
+}
+
+// PerMonthDateGen generates dates filling months in order, every month gets its number of dates.
+// Hour of day is picked according to HourWeights. Once all the months are filled, dates are between 2010 and 2020 years.
+type PerMonthDateGen struct {
+	Months []MonthCount
+	// generated is number of already generated dates.
+	generated int
+}
+
+// Next returns random date within the month of the next date.
+func (g *PerMonthDateGen) Next() time.Time {
+	since, until := MonthOf(g.Months, g.generated)
+	g.generated++
+	return randomDate(since, until, &HourWeights)
+}
+
+// WithDates sets event date drawn from provided generator.
+func WithDates(g DateGenerator) EventOption {
+	return func(b *eventBuilder) {
+		b.dates = g
+	}
+}
@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUniformDateGen(t *testing.T) {
+	since := time.Date(2022, 3, 14, 12, 0, 0, 0, time.UTC)
+	until := since.Add(36 * time.Hour)
+	g := UniformDateGen{Since: since, Until: until}
+
+	hours := map[int]bool{}
+	for i := 0; i < 10000; i++ {
+		date := g.Next()
+		if date.Before(since) || !date.Before(until) {
+			t.Fatalf("expected date within [%v, %v), got %v", since, until, date)
+		}
+		hours[date.Hour()] = true
+	}
+	// every hour of the window is likely enough to be drawn
+	if len(hours) != 24 {
+		t.Errorf("expected dates of every hour of day, got hours %v", hours)
+	}
+}
+
+func TestUniformDateGenWithoutWindow(t *testing.T) {
+	var g UniformDateGen
+	for i := 0; i < 1000; i++ {
+		if date := g.Next(); date.Year() < 2009 || date.Year() > 2020 {
+			t.Fatalf("expected date between 2010 and 2020 years, got %v", date)
+		}
+	}
+}
+
+func TestPeakHoursDateGen(t *testing.T) {
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 1, 0)
+
+	// only 9-10 hours are possible
+	var weights [24]float64
+	weights[9], weights[10] = 1, 1
+	g := PeakHoursDateGen{Since: since, Until: until, Weights: weights}
+
+	for i := 0; i < 1000; i++ {
+		date := g.Next()
+		if date.Before(since) || !date.Before(until) {
+			t.Fatalf("expected date within [%v, %v), got %v", since, until, date)
+		}
+		if date.Hour() != 9 && date.Hour() != 10 {
+			t.Fatalf("expected date within peak hours, got %v", date)
+		}
+	}
+}
+
+func TestPerMonthDateGen(t *testing.T) {
+	january := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	g := &PerMonthDateGen{Months: []MonthCount{{Month: january, Count: 3}, {Month: march, Count: 2}}}
+
+	expected := []time.Time{january, january, january, march, march}
+	for i, month := range expected {
+		date := g.Next()
+		if date.Before(month) || !date.Before(month.AddDate(0, 1, 0)) {
+			t.Errorf("date %d : expected date within %s, got %v", i, month.Format("2006-01"), date)
+		}
+	}
+
+	// months are filled, so dates are no longer bound to them
+	if date := g.Next(); date.Year() < 2009 || date.Year() > 2020 {
+		t.Errorf("expected date between 2010 and 2020 years once months are filled, got %v", date)
+	}
+}
+
+func TestWithDates(t *testing.T) {
+	date := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+	e := NewEvent(WithDates(fixedDates{date}))
+	if !e.EventDate.Equal(date) {
+		t.Errorf("expected event date %v, got %v", date, e.EventDate)
+	}
+}
+
+// fixedDates generates the same date.
+type fixedDates struct {
+	date time.Time
+}
+
+// Next returns the date.
+func (g fixedDates) Next() time.Time {
+	return g.date
+}
+
+func TestParseDate(t *testing.T) {
+	defer func(l *time.Location) { Location = l }(Location)
+	Location = time.FixedZone("UTC+2", 2*60*60)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+		valid    bool
+	}{
+		{input: "2022-03-14T15:09:26Z", expected: time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC), valid: true},
+		// dates without time are midnight of configured location
+		{input: "2022-03-14", expected: time.Date(2022, 3, 13, 22, 0, 0, 0, time.UTC), valid: true},
+		{input: "2022-02-30"},
+		{input: "14.03.2022"},
+		{input: ""},
+	}
+
+	for _, test := range tests {
+		parsed, err := ParseDate(test.input)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("expected '%s' to be rejected, got %v", test.input, parsed)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unable to parse '%s' : %v", test.input, err)
+		} else if !parsed.Equal(test.expected) {
+			t.Errorf("'%s' : expected %v, got %v", test.input, test.expected, parsed)
+		}
+	}
+}
+
+func TestRandomDateBetween(t *testing.T) {
+	tests := []struct {
+		name         string
+		since, until time.Time
+	}{
+		{name: "billing period", since: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), until: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "single hour", since: time.Date(2022, 3, 14, 15, 0, 0, 0, time.UTC), until: time.Date(2022, 3, 14, 16, 0, 0, 0, time.UTC)},
+		{name: "across midnight", since: time.Date(2022, 3, 14, 23, 30, 0, 0, time.UTC), until: time.Date(2022, 3, 15, 0, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 1000; i++ {
+			date := RandomDateBetween(test.since, test.until)
+			if date.Before(test.since) || !date.Before(test.until) {
+				t.Fatalf("%s : expected date within [%v, %v), got %v", test.name, test.since, test.until, date)
+			}
+		}
+	}
+}
+
+func TestRandomDateBetweenPanicsOnInvalidRange(t *testing.T) {
+	date := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+	for _, until := range []time.Time{date, date.Add(-time.Second)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic of range %v - %v", date, until)
+				}
+			}()
+			RandomDateBetween(date, until)
+		}()
+	}
+}
+
+func TestRandomDateWithinSinceUntil(t *testing.T) {
+	defer func(since, until time.Time) { Since, Until = since, until }(Since, Until)
+	Since = time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	Until = time.Date(2022, 3, 8, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 1000; i++ {
+		if date := RandomDate(); date.Before(Since) || !date.Before(Until) {
+			t.Fatalf("expected date within [%v, %v), got %v", Since, Until, date)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// DeriveRule sets attribute to a deterministic function of another event field,
+// so the same field value always yields the same attribute, e.g. device id of calling number.
+type DeriveRule struct {
+	// Attr is number of derived attribute from 1 to 8.
+	Attr int
+	// Func is name of applied function, one of DeriveHash or DeriveValue.
+	Func string
+	// Field is json name of the source field.
+	Field string
+}
+
+const (
+	// DeriveHash derives 16 hex characters of SHA-256 of the field value.
+	DeriveHash = "hash"
+	// DeriveValue copies the field value.
+	DeriveValue = "value"
+)
+
+// deriveLen is number of hex characters of derived hashes.
+const deriveLen = 16
+
+// deriveFields are string values of fields attributes could be derived from.
+var deriveFields = map[string]func(e *model.Event) string{
+	"event_source":     func(e *model.Event) string { return strconv.Itoa(e.EventSource) },
+	"event_ref":        func(e *model.Event) string { return e.EventRef },
+	"event_type":       func(e *model.Event) string { return strconv.Itoa(int(e.EventType)) },
+	"event_date":       func(e *model.Event) string { return e.EventDate.Format(time.RFC3339Nano) },
+	"calling_number":   func(e *model.Event) string { return strconv.Itoa(e.CallingNumber) },
+	"called_number":    func(e *model.Event) string { return strconv.Itoa(e.CalledNumber) },
+	"location":         func(e *model.Event) string { return e.Location },
+	"duration_seconds": func(e *model.Event) string { return strconv.Itoa(e.DurationSeconds) },
+}
+
+// ParseDeriveRules parses comma separated rules like attr3=hash(calling_number),attr4=value(location).
+func ParseDeriveRules(s string) ([]DeriveRule, error) {
+	var rules []DeriveRule
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		attr, expr, ok := strings.Cut(part, "=")
+		if !ok || !strings.HasPrefix(attr, "attr") {
+			return nil, invalidArgs("invalid rule '%s', attr<number>=<func>(<field>) expected", part)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(attr[len("attr"):]))
+		if err != nil || n < 1 || n > len(AttributeStrategies) {
+			return nil, invalidArgs("invalid attribute '%s', attr1-attr%d expected", attr, len(AttributeStrategies))
+		}
+
+		expr = strings.TrimSpace(expr)
+		fn, field, ok := strings.Cut(strings.TrimSuffix(expr, ")"), "(")
+		if !ok || !strings.HasSuffix(expr, ")") {
+			return nil, invalidArgs("invalid rule '%s', <func>(<field>) expected", part)
+		}
+		if fn != DeriveHash && fn != DeriveValue {
+			return nil, invalidArgs("unknown function '%s', %s or %s expected", fn, DeriveHash, DeriveValue)
+		}
+		if _, ok := deriveFields[field]; !ok {
+			return nil, invalidArgs("unknown field '%s' of rule '%s'", field, part)
+		}
+
+		rules = append(rules, DeriveRule{Attr: n, Func: fn, Field: field})
+	}
+	return rules, nil
+}
+
+// WithDerivedAttributes sets attributes by provided rules once the rest of event fields are final.
+func WithDerivedAttributes(rules []DeriveRule) EventOption {
+	return func(b *eventBuilder) {
+		b.derived = append(b.derived, rules...)
+	}
+}
+
+// derive will set attributes of event by provided rules.
+func derive(e *model.Event, rules []DeriveRule) {
+	attrs := e.Attributes()
+	for _, r := range rules {
+		value := deriveFields[r.Field](e)
+		if r.Func == DeriveHash {
+			sum := sha256.Sum256([]byte(value))
+			value = hex.EncodeToString(sum[:])[:deriveLen]
+		}
+		attrs[r.Attr-1] = value
+	}
+	e.SetAttributes(attrs)
+}
@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeriveHashIsDeterministic(t *testing.T) {
+	rules, err := ParseDeriveRules("attr3=hash(calling_number)")
+	if err != nil {
+		t.Fatalf("unable to parse derive rules : %v", err)
+	}
+
+	// events share calling number only, the rest of fields are random
+	var hash string
+	for i := 0; i < 10; i++ {
+		e := RandomEvent(Options{Events: []EventOption{WithNumbers(380501234567, i), WithDerivedAttributes(rules)}})
+		if len(e.Attr3) != deriveLen {
+			t.Fatalf("expected %d characters of hash, got %q", deriveLen, e.Attr3)
+		}
+		if hash == "" {
+			hash = e.Attr3
+		} else if e.Attr3 != hash {
+			t.Errorf("event %d : expected attr3 %s of the same calling number, got %s", i, hash, e.Attr3)
+		}
+	}
+
+	other := NewEvent(WithNumbers(380507654321, 0), WithDerivedAttributes(rules))
+	if other.Attr3 == hash {
+		t.Errorf("expected attr3 of different calling number to differ, got %s", other.Attr3)
+	}
+}
+
+func TestDeriveValue(t *testing.T) {
+	rules, err := ParseDeriveRules("attr4=value(location), attr1=value(calling_number)")
+	if err != nil {
+		t.Fatalf("unable to parse derive rules : %v", err)
+	}
+
+	e := NewEvent(WithLocation("KYIV"), WithNumbers(42, 7), WithAttributes([8]string{"a", "b", "c", "d", "e"}), WithDerivedAttributes(rules))
+	if e.Attr4 != "KYIV" || e.Attr1 != "42" {
+		t.Errorf("expected attr1 42 and attr4 KYIV, got %s and %s", e.Attr1, e.Attr4)
+	}
+	// attributes without rules are kept
+	if e.Attr2 != "b" || e.Attr5 != "e" {
+		t.Errorf("expected not derived attributes to be kept, got %+v", e)
+	}
+}
+
+func TestParseDeriveRulesRejectsMalformed(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"attr3",
+		"attr9=hash(calling_number)",
+		"attr0=hash(calling_number)",
+		"field3=hash(calling_number)",
+		"attr3=hash(calling_number",
+		"attr3=hash",
+		"attr3=md5(calling_number)",
+		"attr3=hash(imsi)",
+		"attr3=hash(calling_number),attr4",
+	} {
+		if _, err := ParseDeriveRules(input); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("expected '%s' to be rejected with invalid arguments error, got %v", input, err)
+		}
+	}
+}
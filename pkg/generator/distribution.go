@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// Distribution picks event types according to configured weights.
+type Distribution struct {
+	types      []model.EventType
+	cumulative []float64
+	total      float64
+}
+
+// NewDistribution creates distribution of event types from provided weights.
+// Weights don't have to sum up to 100, they are normalized.
+func NewDistribution(weights map[model.EventType]float64) (*Distribution, error) {
+	if len(weights) == 0 {
+		return nil, invalidArgs("distribution must contain at least one event type")
+	}
+
+	types := make([]model.EventType, 0, len(weights))
+	for t, w := range weights {
+		if w < 0 {
+			return nil, invalidArgs("weight of event type %d must not be negative, got %v", t, w)
+		}
+		types = append(types, t)
+	}
+	// sort types so the same seed always produces the same sequence
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	d := &Distribution{types: types, cumulative: make([]float64, 0, len(types))}
+	for _, t := range types {
+		d.total += weights[t]
+		d.cumulative = append(d.cumulative, d.total)
+	}
+
+	if d.total == 0 {
+		return nil, invalidArgs("sum of event type weights must be positive")
+	}
+	return d, nil
+}
+
+// DefaultDistribution returns distribution of event types we have to generate:
+// * type 1 - 15%
+// * type 2 - 20%
+// * type 3 - 20%
+// * type 5 - 45%
+func DefaultDistribution() *Distribution {
+	d, _ := NewDistribution(map[model.EventType]float64{
+		model.EventTypeStandardCall:   15,
+		model.EventTypeSMS:            20,
+		model.EventTypePremiumService: 20,
+		model.EventTypeDataSession:    45,
+	})
+	return d
+}
+
+// ParseDistribution parses distribution like "1:15,2:20,3:20,5:45" where every entry is
+// event type (number or label) and its weight. Every type must be one of model.ValidEventTypes.
+func ParseDistribution(s string) (*Distribution, error) {
+	weights := map[model.EventType]float64{}
+
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			return nil, invalidArgs("invalid distribution entry '%s', <type>:<weight> expected", entry)
+		}
+
+		t, err := model.ParseEventType(parts[0])
+		if err != nil {
+			return nil, invalidArgs("%v", err)
+		}
+		if _, ok := weights[t]; ok {
+			return nil, invalidArgs("event type %d is duplicated", int(t))
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, invalidArgs("invalid weight of event type %d : %v", int(t), err)
+		}
+		weights[t] = weight
+	}
+
+	return NewDistribution(weights)
+}
+
+// Percentages returns expected percentage of every event type.
+func (d *Distribution) Percentages() map[model.EventType]float64 {
+	percentages := make(map[model.EventType]float64, len(d.types))
+	prev := 0.0
+	for i, t := range d.types {
+		percentages[t] = (d.cumulative[i] - prev) / d.total * 100
+		prev = d.cumulative[i]
+	}
+	return percentages
+}
+
+// EventType will pick a random event type according to the distribution.
+func (d *Distribution) EventType() model.EventType {
+	r := randFloat64() * d.total
+
+	i := sort.SearchFloat64s(d.cumulative, r)
+	// r equal to boundary belongs to the next type
+	for i < len(d.cumulative)-1 && d.cumulative[i] <= r {
+		i++
+	}
+	return d.types[i]
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// DurationProfile generates event durations in seconds.
+type DurationProfile func() int
+
+// UniformDuration returns profile of durations uniformly distributed in [0,max).
+func UniformDuration(max int) DurationProfile {
+	return func() int {
+		return randIntn(max)
+	}
+}
+
+// ExponentialDuration returns long tailed profile of durations with provided mean,
+// most of the durations are short while some of them are many times longer than the mean.
+func ExponentialDuration(mean float64) DurationProfile {
+	return func() int {
+		return int(randExpFloat64() * mean)
+	}
+}
+
+// DurationProfiles are duration profiles by event type,
+// durations of types without profile are uniformly distributed up to 100 seconds.
+var DurationProfiles = map[model.EventType]DurationProfile{}
+
+// randomDuration returns duration of event of provided type.
+func randomDuration(t model.EventType) int {
+	if profile, ok := DurationProfiles[t]; ok {
+		return profile()
+	}
+	return randIntn(maxDuration)
+}
+
+// ParseDurationProfiles parses profiles like 1:uniform:60,5:exp:600, where uniform is followed by the max
+// duration and exp by the mean duration in seconds.
+func ParseDurationProfiles(s string) (map[model.EventType]DurationProfile, error) {
+	profiles := map[model.EventType]DurationProfile{}
+
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, invalidArgs("invalid duration profile '%s', <type>:<uniform|exp>:<seconds> expected", entry)
+		}
+
+		t, err := model.ParseEventType(parts[0])
+		if err != nil {
+			return nil, invalidArgs("%v", err)
+		}
+		if _, ok := profiles[t]; ok {
+			return nil, invalidArgs("event type %d is duplicated", int(t))
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil || seconds <= 0 {
+			return nil, invalidArgs("invalid duration of event type %d, positive number of seconds expected", int(t))
+		}
+
+		switch strings.TrimSpace(parts[1]) {
+		case "uniform":
+			profiles[t] = UniformDuration(seconds)
+		case "exp":
+			profiles[t] = ExponentialDuration(float64(seconds))
+		default:
+			return nil, invalidArgs("unknown duration profile '%s' of event type %d, uniform or exp expected", parts[1], int(t))
+		}
+	}
+	return profiles, nil
+}
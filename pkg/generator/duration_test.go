@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestDurationProfilesMeanByType(t *testing.T) {
+	defer func(profiles map[model.EventType]DurationProfile) { DurationProfiles = profiles }(DurationProfiles)
+	profiles, err := ParseDurationProfiles("1:uniform:60,5:exp:600")
+	if err != nil {
+		t.Fatalf("unable to parse duration profiles : %v", err)
+	}
+	DurationProfiles = profiles
+
+	sums := map[model.EventType]int{}
+	counts := map[model.EventType]int{}
+	longest := map[model.EventType]int{}
+	for i := 0; i < 50000; i++ {
+		e := RandomEvent(Options{})
+		sums[e.EventType] += e.DurationSeconds
+		counts[e.EventType]++
+		if e.DurationSeconds > longest[e.EventType] {
+			longest[e.EventType] = e.DurationSeconds
+		}
+	}
+
+	// durations are whole seconds, so they are half a second shorter on average
+	expected := map[model.EventType]float64{
+		model.EventTypeStandardCall: 29.5,
+		model.EventTypeDataSession:  599.5,
+		// types without profile keep the default uniform durations
+		model.EventTypeSMS: 49.5,
+	}
+	for eventType, mean := range expected {
+		if counts[eventType] == 0 {
+			t.Fatalf("expected events of type %v", eventType)
+		}
+		if actual := float64(sums[eventType]) / float64(counts[eventType]); math.Abs(actual-mean) > mean*0.1 {
+			t.Errorf("%v : expected mean duration %v, got %v", eventType, mean, actual)
+		}
+	}
+
+	if longest[model.EventTypeStandardCall] >= 60 {
+		t.Errorf("expected standard calls shorter than 60 seconds, got %d", longest[model.EventTypeStandardCall])
+	}
+	// exponential profile is long tailed
+	if longest[model.EventTypeDataSession] < 3*600 {
+		t.Errorf("expected data sessions many times longer than the mean, got at most %d", longest[model.EventTypeDataSession])
+	}
+}
+
+func TestParseDurationProfilesRejectsInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"1:uniform",
+		"1:uniform:60,1:exp:60",
+		"9:uniform:60",
+		"1:uniform:0",
+		"1:uniform:minute",
+		"1:normal:60",
+	} {
+		if _, err := ParseDurationProfiles(input); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("expected '%s' to be rejected with invalid arguments error, got %v", input, err)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidArgs is wrapped by errors of invalid generator configuration, e.g. malformed distribution.
+	ErrInvalidArgs = errors.New("invalid arguments")
+	// ErrBadInput is wrapped by errors of malformed serialized events.
+	ErrBadInput = errors.New("bad input")
+)
+
+// invalidArgs returns formatted error wrapping ErrInvalidArgs.
+func invalidArgs(format string, args ...interface{}) error {
+	return fmt.Errorf(format+" : %w", append(args, ErrInvalidArgs)...)
+}
+
+// badInput returns formatted error wrapping ErrBadInput.
+func badInput(format string, args ...interface{}) error {
+	return fmt.Errorf(format+" : %w", append(args, ErrBadInput)...)
+}
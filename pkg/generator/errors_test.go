@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorsAreWrapped(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func() error
+		expected error
+	}{
+		{name: "malformed distribution", fn: func() error {
+			_, err := ParseDistribution("sms=lots")
+			return err
+		}, expected: ErrInvalidArgs},
+		{name: "malformed hour window", fn: func() error {
+			_, _, err := ParseHourWindow("9")
+			return err
+		}, expected: ErrInvalidArgs},
+		{name: "out of range peak hours", fn: func() error {
+			_, err := PeakHourWeights(0, 25, 2)
+			return err
+		}, expected: ErrInvalidArgs},
+		{name: "malformed date", fn: func() error {
+			_, err := ParseDate("yesterday")
+			return err
+		}, expected: ErrInvalidArgs},
+		{name: "unknown ref version", fn: func() error {
+			_, err := NewRefGenerator(9, false)
+			return err
+		}, expected: ErrInvalidArgs},
+		{name: "unknown format", fn: func() error {
+			_, err := ReadEvents(strings.NewReader(""), "xml")
+			return err
+		}, expected: ErrInvalidArgs},
+		{name: "malformed json", fn: func() error {
+			_, err := ReadJSON(strings.NewReader("[{"))
+			return err
+		}, expected: ErrBadInput},
+		{name: "json object instead of array", fn: func() error {
+			return StreamJSON(strings.NewReader("{}"), nil)
+		}, expected: ErrBadInput},
+		{name: "malformed csv record", fn: func() error {
+			_, err := ReadCSV(strings.NewReader("event_source\nsource,extra\n"))
+			return err
+		}, expected: ErrBadInput},
+	}
+
+	for _, test := range tests {
+		err := test.fn()
+		if !errors.Is(err, test.expected) {
+			t.Errorf("%s : expected %v, got %v", test.name, test.expected, err)
+		}
+		// categories are distinct, so callers could tell configuration from data errors
+		for _, other := range []error{ErrInvalidArgs, ErrBadInput} {
+			if other != test.expected && errors.Is(err, other) {
+				t.Errorf("%s : expected error not to wrap %v, got %v", test.name, other, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"github.com/google/uuid"
+)
+
+// DefaultNumberMax is default upper bound of generated event sources and phone numbers.
+const DefaultNumberMax = 88005553535
+
+// SourceMax and NumberMax are exclusive upper bounds of generated event sources and phone numbers.
+// Bounds are limited by int size, so on 32-bit platforms values don't exceed math.MaxInt32.
+var (
+	SourceMax int64 = DefaultNumberMax
+	NumberMax int64 = DefaultNumberMax
+)
+
+// MillisDurations enables sub-second durations, DurationMillis is set and DurationSeconds is derived from it.
+var MillisDurations bool
+
+// maxDuration is upper bound of generated event durations in seconds.
+const maxDuration = 100
+
+// EventOption customizes event created by NewEvent.
+type EventOption func(b *eventBuilder)
+
+// eventBuilder collects options of a single event.
+type eventBuilder struct {
+	refs    *RefGenerator
+	dist    *Distribution
+	setters []func(e *model.Event)
+	// duration is explicitly set duration, it's drawn by event type if nil.
+	duration *int
+	// dates generates event date, RandomDate is used if nil.
+	dates DateGenerator
+	// derived are rules of attributes derived from the final event fields.
+	derived []DeriveRule
+}
+
+// NewEvent creates a fully populated event, fields not set by options get random values.
+//
+// For example 100 type 3 calls on a specific date:
+//
+//	for i := 0; i < 100; i++ {
+//		events = append(events, NewEvent(WithType(model.EventTypePremiumService), WithDate(date)))
+//	}
+func NewEvent(opts ...EventOption) *model.Event {
+	b := &eventBuilder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	var ref string
+	if b.refs != nil {
+		ref = b.refs.Next()
+	} else {
+		ref = uuid.New().String()
+	}
+
+	dist := b.dist
+	if dist == nil {
+		dist = defaultDistribution
+	}
+
+	var date time.Time
+	if b.dates != nil {
+		date = b.dates.Next()
+	} else {
+		date = *RandomDate()
+	}
+
+	e := &model.Event{
+		EventSource:   randNumber(SourceMax),
+		EventRef:      ref,
+		EventType:     dist.EventType(),
+		EventDate:     date,
+		CallingNumber: randNumber(NumberMax),
+		CalledNumber:  randNumber(NumberMax),
+		Location:      LocationCode(),
+	}
+	e.SetAttributes(RandomAttributes())
+
+	for _, set := range b.setters {
+		set(e)
+	}
+
+	// duration depends on type, so it's drawn once the type is final
+	if b.duration != nil {
+		e.DurationSeconds = *b.duration
+		if MillisDurations {
+			e.DurationMillis = e.DurationSeconds * 1000
+		}
+	} else {
+		e.DurationSeconds = randomDuration(e.EventType)
+		if MillisDurations {
+			e.DurationMillis = e.DurationSeconds*1000 + randIntn(1000)
+			e.DurationSeconds = e.DurationMillis / 1000
+		}
+	}
+
+	if len(b.derived) > 0 {
+		derive(e, b.derived)
+	}
+	return e
+}
+
+// Options configure events created by RandomEvent, zero value creates events like NewEvent without options.
+// Random values are drawn from the source configured by SetSource, so seeded source makes events reproducible.
+type Options struct {
+	// Refs generates event refs, uuid.New is used if nil.
+	Refs *RefGenerator
+	// Distribution of event types, DefaultDistribution is used if nil.
+	Distribution *Distribution
+	// Since and Until limit event dates, package wide Since and Until are used if both are zero.
+	// Missing bound is taken from package wide Since or Until, or from 2010-2020 years window if they are not set.
+	Since, Until time.Time
+	// HourWeights weight hour of day of event dates, package wide HourWeights are used if nil.
+	HourWeights *[24]float64
+	// Dates generates event dates, it takes precedence over Since and Until.
+	Dates DateGenerator
+	// Sessions splits events into multi-part sessions if set.
+	Sessions *Sessions
+	// Overrides replace default generators of event fields.
+	Overrides FieldOverrides
+	// Events are additional options of every event.
+	Events []EventOption
+}
+
+// RandomEvent creates a random event configured by options.
+func RandomEvent(opts Options) *model.Event {
+	eventOptions := make([]EventOption, 0, len(opts.Events)+3)
+	eventOptions = append(eventOptions, WithRandomDefaults(opts.Refs, opts.Distribution), WithOverrides(opts.Overrides))
+	if opts.Dates != nil {
+		eventOptions = append(eventOptions, WithDates(opts.Dates))
+	} else if !opts.Since.IsZero() || !opts.Until.IsZero() || opts.HourWeights != nil {
+		since, until := opts.dateWindow()
+		weights := opts.HourWeights
+		if weights == nil {
+			weights = &HourWeights
+		}
+		eventOptions = append(eventOptions, WithDate(dateBetween(since, until, weights)))
+	}
+	eventOptions = append(eventOptions, opts.Events...)
+
+	if opts.Sessions != nil {
+		return opts.Sessions.Next(eventOptions...)
+	}
+	return NewEvent(eventOptions...)
+}
+
+// dateWindow returns bounds of event dates, bounds which are not set by options are filled from package wide
+// Since and Until or from 2010-2020 years window.
+func (o Options) dateWindow() (time.Time, time.Time) {
+	since, until := o.Since, o.Until
+	if since.IsZero() {
+		since = Since
+		if since.IsZero() {
+			since = time.Date(2010, 1, 1, 0, 0, 0, 0, Location)
+		}
+	}
+	if until.IsZero() {
+		until = Until
+		if until.IsZero() {
+			until = time.Date(2021, 1, 1, 0, 0, 0, 0, Location)
+		}
+	}
+	return since, until
+}
+
+// defaultDistribution is used when distribution is not configured by options.
+var defaultDistribution = DefaultDistribution()
+
+// WithRandomDefaults configures generators of random event ref and type,
+// nil values keep uuid.New refs and DefaultDistribution.
+func WithRandomDefaults(refs *RefGenerator, dist *Distribution) EventOption {
+	return func(b *eventBuilder) {
+		b.refs = refs
+		b.dist = dist
+	}
+}
+
+// with adds setter of event fields.
+func with(set func(e *model.Event)) EventOption {
+	return func(b *eventBuilder) {
+		b.setters = append(b.setters, set)
+	}
+}
+
+// WithRef sets event ref.
+func WithRef(ref string) EventOption {
+	return with(func(e *model.Event) { e.EventRef = ref })
+}
+
+// WithType sets event type.
+func WithType(t model.EventType) EventOption {
+	return with(func(e *model.Event) { e.EventType = t })
+}
+
+// WithDate sets event date.
+func WithDate(date time.Time) EventOption {
+	return with(func(e *model.Event) { e.EventDate = date })
+}
+
+// WithDuration sets event duration in seconds.
+func WithDuration(seconds int) EventOption {
+	return func(b *eventBuilder) {
+		b.duration = &seconds
+	}
+}
+
+// WithSource sets event source.
+func WithSource(source int) EventOption {
+	return with(func(e *model.Event) { e.EventSource = source })
+}
+
+// WithNumbers sets calling and called numbers.
+func WithNumbers(calling, called int) EventOption {
+	return with(func(e *model.Event) {
+		e.CallingNumber = calling
+		e.CalledNumber = called
+	})
+}
+
+// WithLocation sets event location.
+func WithLocation(location string) EventOption {
+	return with(func(e *model.Event) { e.Location = location })
+}
+
+// WithAttributes sets Attr1-Attr8.
+func WithAttributes(attrs [8]string) EventOption {
+	return with(func(e *model.Event) { e.SetAttributes(attrs) })
+}
@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// withMillisDurations sets MillisDurations for the test.
+func withMillisDurations(t *testing.T, enabled bool) {
+	t.Helper()
+	prev := MillisDurations
+	t.Cleanup(func() { MillisDurations = prev })
+	MillisDurations = enabled
+}
+
+func TestMillisDurationsRoundTrip(t *testing.T) {
+	withMillisDurations(t, true)
+
+	events := make(model.Events, 0, 1000)
+	subSecond := false
+	for i := 0; i < cap(events); i++ {
+		e := RandomEvent(Options{})
+		if e.DurationSeconds != e.DurationMillis/1000 {
+			t.Fatalf("expected seconds derived from %dms, got %ds", e.DurationMillis, e.DurationSeconds)
+		}
+		subSecond = subSecond || e.DurationMillis%1000 != 0
+		events = append(events, e)
+	}
+	if !subSecond {
+		t.Error("expected sub-second durations")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, events); err != nil {
+		t.Fatalf("unable to write events : %v", err)
+	}
+	parsed, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("unable to read events : %v", err)
+	}
+	assertEvents(t, events, parsed)
+}
+
+func TestMillisDurationOfExplicitDuration(t *testing.T) {
+	withMillisDurations(t, true)
+	e := NewEvent(WithDuration(12))
+	if e.DurationSeconds != 12 || e.DurationMillis != 12000 {
+		t.Errorf("expected 12s and 12000ms, got %ds and %dms", e.DurationSeconds, e.DurationMillis)
+	}
+}
+
+func TestSecondDurationsOmitMillis(t *testing.T) {
+	withMillisDurations(t, false)
+	e := RandomEvent(Options{})
+	if e.DurationMillis != 0 {
+		t.Fatalf("expected no millis, got %dms", e.DurationMillis)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, model.Events{e}); err != nil {
+		t.Fatalf("unable to write events : %v", err)
+	}
+	if strings.Contains(buf.String(), "duration_millis") {
+		t.Errorf("expected duration_millis to be omitted, got %s", buf.String())
+	}
+}
+
+// typePercentages returns percentage of every event type of provided number of random events.
+func typePercentages(opts Options, n int) map[model.EventType]float64 {
+	counts := map[model.EventType]int{}
+	for i := 0; i < n; i++ {
+		counts[RandomEvent(opts).EventType]++
+	}
+	percentages := map[model.EventType]float64{}
+	for t, count := range counts {
+		percentages[t] = float64(count) / float64(n) * 100
+	}
+	return percentages
+}
+
+func TestRandomEventTypeDistribution(t *testing.T) {
+	custom, err := ParseDistribution("sms:10,roaming:30,data_session:60")
+	if err != nil {
+		t.Fatalf("unable to parse distribution : %v", err)
+	}
+
+	for name, opts := range map[string]Options{"default": {}, "custom": {Distribution: custom}} {
+		expected := DefaultDistribution().Percentages()
+		if opts.Distribution != nil {
+			expected = opts.Distribution.Percentages()
+		}
+
+		actual := typePercentages(opts, 50000)
+		if len(actual) != len(expected) {
+			t.Errorf("%s : expected types %v, got %v", name, expected, actual)
+		}
+		for eventType, percentage := range expected {
+			if math.Abs(actual[eventType]-percentage) > 1 {
+				t.Errorf("%s : expected %.2f%% of type %d, got %.2f%%", name, percentage, int(eventType), actual[eventType])
+			}
+		}
+	}
+}
+
+func TestRandomEventDateRange(t *testing.T) {
+	since := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	for i := 0; i < 1000; i++ {
+		if date := RandomEvent(Options{Since: since, Until: until}).EventDate; date.Before(since) || !date.Before(until) {
+			t.Fatalf("expected date within [%v, %v), got %v", since, until, date)
+		}
+	}
+}
+
+func TestRandomEventFillsMissingBound(t *testing.T) {
+	defer func(since, until time.Time) { Since, Until = since, until }(Since, Until)
+	date := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		pkgSince, pkgUntil time.Time
+		opts               Options
+		expSince, expUntil time.Time
+	}{
+		{
+			name:     "only since",
+			opts:     Options{Since: date},
+			expSince: date, expUntil: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "only until",
+			opts:     Options{Until: date},
+			expSince: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), expUntil: date,
+		},
+		{
+			name:     "until of package",
+			pkgSince: date.AddDate(0, 0, -7), pkgUntil: date.AddDate(0, 0, 7),
+			opts:     Options{Since: date},
+			expSince: date, expUntil: date.AddDate(0, 0, 7),
+		},
+		{
+			name:     "since of package",
+			pkgSince: date.AddDate(0, 0, -7), pkgUntil: date.AddDate(0, 0, 7),
+			opts:     Options{Until: date},
+			expSince: date.AddDate(0, 0, -7), expUntil: date,
+		},
+	}
+
+	for _, test := range tests {
+		Since, Until = test.pkgSince, test.pkgUntil
+		for i := 0; i < 1000; i++ {
+			if date := RandomEvent(test.opts).EventDate; date.Before(test.expSince) || !date.Before(test.expUntil) {
+				t.Fatalf("%s : expected date within [%v, %v), got %v", test.name, test.expSince, test.expUntil, date)
+			}
+		}
+	}
+}
+
+func TestRandomEventHourWeights(t *testing.T) {
+	since := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+
+	// only 9 hour is possible
+	var weights [24]float64
+	weights[9] = 1
+	for i := 0; i < 1000; i++ {
+		if date := RandomEvent(Options{Since: since, Until: until, HourWeights: &weights}).EventDate; date.Hour() != 9 {
+			t.Fatalf("expected date within 9 hour, got %v", date)
+		}
+	}
+}
+
+func TestRandomEventReproducibleWithSeed(t *testing.T) {
+	defer SetSource(nil)
+
+	generate := func() model.Events {
+		SetSource(rand.NewSource(42))
+		events := make(model.Events, 0, 100)
+		for i := 0; i < cap(events); i++ {
+			e := RandomEvent(Options{})
+			// default refs are random UUIDs not depending on the seed
+			e.EventRef = ""
+			events = append(events, e)
+		}
+		return events
+	}
+	assertEvents(t, generate(), generate())
+}
+
+func TestDistributionOfConfiguredTypes(t *testing.T) {
+	defer func(types []model.EventType) { model.ValidEventTypes = types }(model.ValidEventTypes)
+	model.ValidEventTypes = []model.EventType{model.EventTypeStandardCall, model.EventTypeRoaming, 7}
+
+	if _, err := ParseDistribution("1:50,data_session:50"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("expected type out of configured ones to be rejected, got %v", err)
+	}
+
+	custom, err := ParseDistribution("standard_call:20,4:30,7:50")
+	if err != nil {
+		t.Fatalf("unable to parse distribution : %v", err)
+	}
+	actual := typePercentages(Options{Distribution: custom}, 50000)
+	expected := map[model.EventType]float64{model.EventTypeStandardCall: 20, model.EventTypeRoaming: 30, 7: 50}
+	if len(actual) != len(expected) {
+		t.Errorf("expected types %v, got %v", expected, actual)
+	}
+	for eventType, percentage := range expected {
+		if math.Abs(actual[eventType]-percentage) > 1 {
+			t.Errorf("expected %.2f%% of type %d, got %.2f%%", percentage, int(eventType), actual[eventType])
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func ExampleNewEvent() {
+	date := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+	e := NewEvent(WithType(model.EventTypeSMS), WithDate(date), WithDuration(12), WithLocation("KYIV"))
+
+	fmt.Println(e.EventType, e.EventDate, e.DurationSeconds, e.Location)
+	// fields which are not set get random values
+	fmt.Println(e.Validate() == nil)
+	// Output:
+	// sms 2022-03-14 15:09:26 +0000 UTC 12 KYIV
+	// true
+}
+
+func ExampleNewEvent_fixture() {
+	date := time.Date(2022, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	// 100 premium service calls on a specific date
+	var events model.Events
+	for i := 0; i < 100; i++ {
+		events = append(events, NewEvent(WithType(model.EventTypePremiumService), WithDate(date)))
+	}
+
+	earliest, latest := events.EarliestLatest()
+	fmt.Println(events.CountByType(), earliest.Equal(latest))
+	// Output: map[premium_service:100] true
+}
+
+func ExampleWithRandomDefaults() {
+	refs, err := NewRefGenerator(7, false)
+	if err != nil {
+		panic(err)
+	}
+	dist, err := ParseDistribution("roaming:1")
+	if err != nil {
+		panic(err)
+	}
+
+	e := NewEvent(WithRandomDefaults(refs, dist))
+	fmt.Println(e.EventType, len(e.EventRef))
+	// Output: roaming 36
+}
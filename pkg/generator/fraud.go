@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// subscriberFactor is number of subscriber numbers following fraud prefix, e.g. 900 1234567.
+const subscriberFactor = 10000000
+
+// maxPrefixLen keeps numbers with prefix within int64 range.
+const maxPrefixLen = 11
+
+// ParsePrefixes parses comma separated number prefixes like 900,976.
+func ParsePrefixes(s string) ([]int, error) {
+	var prefixes []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		prefix, err := strconv.Atoi(part)
+		if err != nil || prefix <= 0 || strings.HasPrefix(part, "0") {
+			return nil, invalidArgs("prefix '%s', digits not starting with 0 expected", part)
+		}
+		if len(part) > maxPrefixLen || int64(prefix)*subscriberFactor > math.MaxInt {
+			return nil, invalidArgs("prefix '%s' is too long for the platform", part)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// CheckPrefixes checks that every number of every prefix, e.g. 9000000000-9009999999 of 900 prefix, is below max,
+// so fraud numbers respect upper bound of generated numbers.
+func CheckPrefixes(prefixes []int, max int64) error {
+	for _, prefix := range prefixes {
+		if int64(prefix) >= max/subscriberFactor {
+			return invalidArgs("numbers of prefix %d don't fit below %d", prefix, max)
+		}
+	}
+	return nil
+}
+
+// WithFraudPrefixes sets called number starting with one of provided prefixes with probability of rate,
+// so fraud detection of premium numbers could be tested. Prefixes are expected to fit NumberMax, see CheckPrefixes.
+func WithFraudPrefixes(prefixes []int, rate float64) EventOption {
+	return with(func(e *model.Event) {
+		if len(prefixes) == 0 || randFloat64() >= rate {
+			return
+		}
+		e.CalledNumber = prefixes[randIntn(len(prefixes))]*subscriberFactor + randIntn(subscriberFactor)
+	})
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParsePrefixes(t *testing.T) {
+	prefixes, err := ParsePrefixes("900, 976,1")
+	if err != nil {
+		t.Fatalf("unable to parse prefixes : %v", err)
+	}
+	if len(prefixes) != 3 || prefixes[0] != 900 || prefixes[1] != 976 || prefixes[2] != 1 {
+		t.Errorf("expected [900 976 1], got %v", prefixes)
+	}
+
+	for _, s := range []string{"", "900,", "0900", "0", "-900", "9a", "123456789012"} {
+		if _, err := ParsePrefixes(s); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("'%s' : expected invalid arguments error, got %v", s, err)
+		}
+	}
+}
+
+func TestFraudRate(t *testing.T) {
+	// regular numbers are shorter than fraud ones, so they can't start with a fraud prefix by chance
+	defer func(max int64) { NumberMax = max }(NumberMax)
+	NumberMax = subscriberFactor / 10
+
+	const n, rate = 20000, 0.1
+	prefixes := []int{900, 976}
+	fraud := map[string]int{}
+	for i := 0; i < n; i++ {
+		e := RandomEvent(Options{Events: []EventOption{WithFraudPrefixes(prefixes, rate)}})
+		called := strconv.Itoa(e.CalledNumber)
+		for _, prefix := range prefixes {
+			if p := strconv.Itoa(prefix); strings.HasPrefix(called, p) && len(called) == len(p)+7 {
+				fraud[p]++
+			}
+		}
+	}
+
+	total := fraud["900"] + fraud["976"]
+	if actual := float64(total) / n; math.Abs(actual-rate) > 0.01 {
+		t.Errorf("expected %.2f of events to have fraud prefix, got %.4f", rate, actual)
+	}
+	// prefixes are picked equally likely
+	if math.Abs(float64(fraud["900"]-fraud["976"])) > 0.2*float64(total) {
+		t.Errorf("expected prefixes to be picked equally likely, got %v", fraud)
+	}
+}
+
+func TestFraudRateBounds(t *testing.T) {
+	defer func(max int64) { NumberMax = max }(NumberMax)
+	NumberMax = subscriberFactor / 10
+
+	for i := 0; i < 1000; i++ {
+		if e := NewEvent(WithFraudPrefixes([]int{900}, 0)); e.CalledNumber >= subscriberFactor {
+			t.Fatalf("expected no fraud numbers, got %d", e.CalledNumber)
+		}
+		if e := NewEvent(WithFraudPrefixes([]int{900}, 1)); e.CalledNumber/subscriberFactor != 900 {
+			t.Fatalf("expected fraud number, got %d", e.CalledNumber)
+		}
+		if e := NewEvent(WithFraudPrefixes(nil, 1)); e.CalledNumber >= subscriberFactor {
+			t.Fatalf("expected no fraud numbers without prefixes, got %d", e.CalledNumber)
+		}
+	}
+}
+
+func TestCheckPrefixes(t *testing.T) {
+	tests := []struct {
+		prefixes []int
+		max      int64
+		valid    bool
+	}{
+		{prefixes: []int{900, 976}, max: DefaultNumberMax, valid: true},
+		// 9769999999 is the greatest number of 976 prefix
+		{prefixes: []int{976}, max: 9770000000, valid: true},
+		{prefixes: []int{976}, max: 9769999999},
+		{prefixes: []int{900, 976}, max: 9500000000},
+		{prefixes: []int{1}, max: subscriberFactor},
+		{max: 1, valid: true},
+	}
+
+	for _, test := range tests {
+		err := CheckPrefixes(test.prefixes, test.max)
+		if test.valid && err != nil {
+			t.Errorf("%v below %d : unable to check prefixes : %v", test.prefixes, test.max, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("%v below %d : expected invalid arguments error, got %v", test.prefixes, test.max, err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// sameEvent reports whether events have the same fields, dates are compared as instants,
+// so events decoded with a different location are still the same.
+func sameEvent(a, b *model.Event) bool {
+	if !a.EventDate.Equal(b.EventDate) {
+		return false
+	}
+	x, y := *a, *b
+	x.EventDate, y.EventDate = time.Time{}, time.Time{}
+	return x == y
+}
+
+// assertEvents fails the test if events differ.
+func assertEvents(t *testing.T, expected, actual model.Events) {
+	t.Helper()
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if !sameEvent(expected[i], actual[i]) {
+			t.Errorf("event %d : expected %+v, got %+v", i, expected[i], actual[i])
+		}
+	}
+}
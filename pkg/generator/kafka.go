@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaBatchSize is number of messages produced to kafka at once.
+const kafkaBatchSize = 1000
+
+// KafkaSink publishes events to kafka topic as json messages keyed by event ref.
+// Messages are produced by batches, the rest of them is flushed on Close.
+type KafkaSink struct {
+	w     *kafka.Writer
+	batch []kafka.Message
+}
+
+// NewKafkaSink creates sink publishing events to provided topic of provided brokers.
+func NewKafkaSink(brokers []string, topic string) (*KafkaSink, error) {
+	if len(brokers) == 0 {
+		return nil, invalidArgs("at least one kafka broker is required")
+	}
+	if topic == "" {
+		return nil, invalidArgs("kafka topic is required")
+	}
+
+	return &KafkaSink{
+		w: &kafka.Writer{
+			Addr:  kafka.TCP(brokers...),
+			Topic: topic,
+			// the same ref always goes to the same partition
+			Balancer:     &kafka.Hash{},
+			BatchSize:    kafkaBatchSize,
+			RequiredAcks: kafka.RequireAll,
+		},
+		batch: make([]kafka.Message, 0, kafkaBatchSize),
+	}, nil
+}
+
+// Write will add event to the current batch and produce the batch when it's full.
+func (s *KafkaSink) Write(e *model.Event) error {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to marshall event : %w", err)
+	}
+
+	s.batch = append(s.batch, kafka.Message{Key: []byte(e.EventRef), Value: value})
+	if len(s.batch) >= kafkaBatchSize {
+		return s.Flush()
+	}
+	return nil
+}
+
+// Flush will produce accumulated messages.
+func (s *KafkaSink) Flush() error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+
+	if err := s.w.WriteMessages(context.Background(), s.batch...); err != nil {
+		return fmt.Errorf("unable to produce %d messages to topic %s : %w", len(s.batch), s.w.Topic, err)
+	}
+	s.batch = s.batch[:0]
+	return nil
+}
+
+// Close will produce the rest of messages and close the writer.
+func (s *KafkaSink) Close() error {
+	if err := s.Flush(); err != nil {
+		s.w.Close()
+		return err
+	}
+	return s.w.Close()
+}
@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// LateArrivals shifts dates of a fraction of events back, so events written in date order
+// simulate network-delayed records arriving after newer ones.
+type LateArrivals struct {
+	rate     float64
+	maxDelay time.Duration
+}
+
+// NewLateArrivals creates late arrivals of provided fraction of events delayed by up to maxDelay.
+func NewLateArrivals(rate float64, maxDelay time.Duration) (*LateArrivals, error) {
+	if rate < 0 || rate > 1 {
+		return nil, invalidArgs("late rate %v, value from 0.0 to 1.0 expected", rate)
+	}
+	if maxDelay <= 0 {
+		return nil, invalidArgs("late delay %v, positive duration expected", maxDelay)
+	}
+	return &LateArrivals{rate: rate, maxDelay: maxDelay}, nil
+}
+
+// Delay will shift event date back by random delay up to max delay with probability of rate.
+// Reports whether event is delayed.
+func (l *LateArrivals) Delay(e *model.Event) bool {
+	if randFloat64() >= l.rate {
+		return false
+	}
+	e.EventDate = e.EventDate.Add(-time.Duration(1 + randInt63n(int64(l.maxDelay))))
+	return true
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestLateArrivalsOutOfOrderFraction(t *testing.T) {
+	const n = 20000
+	since := time.Date(2022, 3, 14, 12, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+
+	for _, test := range []struct {
+		rate  float64
+		delay time.Duration
+	}{
+		{rate: 0, delay: time.Hour},
+		{rate: 0.05, delay: time.Hour},
+		{rate: 0.3, delay: 24 * time.Hour},
+		{rate: 0.5, delay: time.Hour},
+	} {
+		late, err := NewLateArrivals(test.rate, test.delay)
+		if err != nil {
+			t.Fatalf("unable to create late arrivals : %v", err)
+		}
+
+		// events are sorted before they are delayed, the way generator writes them with -sort
+		events := make(model.Events, 0, n)
+		for i := 0; i < n; i++ {
+			events = append(events, RandomEvent(Options{Since: since, Until: until}))
+		}
+		events.SortByDate()
+
+		// events are a fraction of a second apart, so nearly every delayed event goes out of order
+		outOfOrder := 0
+		var latest time.Time
+		for i, e := range events {
+			date := e.EventDate
+			if late.Delay(e) {
+				if shift := date.Sub(e.EventDate); shift <= 0 || shift > test.delay {
+					t.Fatalf("rate %v : expected event shifted back by up to %v, got %v", test.rate, test.delay, shift)
+				}
+			} else if !e.EventDate.Equal(date) {
+				t.Fatalf("rate %v : expected date %v of not delayed event to be kept, got %v", test.rate, date, e.EventDate)
+			}
+
+			if i > 0 && e.EventDate.Before(latest) {
+				outOfOrder++
+			}
+			if e.EventDate.After(latest) {
+				latest = e.EventDate
+			}
+		}
+
+		// 5 standard deviations of binomial fraction, the first event can't be out of order
+		tolerance := 5*math.Sqrt(test.rate*(1-test.rate)/n) + 1.0/n
+		if fraction := float64(outOfOrder) / n; math.Abs(fraction-test.rate) > tolerance {
+			t.Errorf("rate %v : expected out of order fraction %v±%.4f, got %v", test.rate, test.rate, tolerance, fraction)
+		}
+	}
+}
+
+func TestNewLateArrivalsRejectsInvalid(t *testing.T) {
+	for _, test := range []struct {
+		rate  float64
+		delay time.Duration
+	}{
+		{rate: -0.1, delay: time.Hour},
+		{rate: 1.1, delay: time.Hour},
+		{rate: 0.1},
+		{rate: 0.1, delay: -time.Hour},
+	} {
+		if _, err := NewLateArrivals(test.rate, test.delay); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("expected rate %v and delay %v to be rejected, got %v", test.rate, test.delay, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Manifest describes generated file, so its integrity could be verified later.
+type Manifest struct {
+	// File is name of described file.
+	File string `json:"file"`
+	// SHA256 is hex encoded SHA-256 checksum of the file content.
+	SHA256 string `json:"sha256"`
+	// Count is number of events stored in the file.
+	Count int `json:"count"`
+	// GeneratedAt is the time manifest was created.
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// ManifestName returns name of manifest file of provided file, e.g. events.json.sha256.
+func ManifestName(filename string) string {
+	return filename + ".sha256"
+}
+
+// WriteManifest will compute checksum of provided file and write its manifest next to it.
+func WriteManifest(filename string, count int) (*Manifest, error) {
+	sum, err := fileChecksum(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{File: filename, SHA256: sum, Count: count, GeneratedAt: time.Now()}
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshall manifest : %w", err)
+	}
+	if err := os.WriteFile(ManifestName(filename), append(content, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("unable to write manifest : %w", err)
+	}
+	return m, nil
+}
+
+// VerifyManifest will check that provided file matches checksum of its manifest.
+// Returns manifest, so number of events could be checked once the file is read.
+func VerifyManifest(filename string) (*Manifest, error) {
+	content, err := os.ReadFile(ManifestName(filename))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest : %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, badInput("malformed manifest %s : %v", ManifestName(filename), err)
+	}
+
+	sum, err := fileChecksum(filename)
+	if err != nil {
+		return nil, err
+	}
+	if sum != m.SHA256 {
+		return nil, badInput("checksum of %s is %s, manifest expects %s", filename, sum, m.SHA256)
+	}
+	return &m, nil
+}
+
+// fileChecksum returns hex encoded SHA-256 checksum of the file, file is streamed, so it doesn't have to fit in memory.
+func fileChecksum(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("unable to open file : %w", err)
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("unable to read file : %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeManifestedFile writes file with provided content and its manifest.
+func writeManifestedFile(t *testing.T, content string, count int) (string, *Manifest) {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "events.ndjson")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("unable to write file : %v", err)
+	}
+	m, err := WriteManifest(filename, count)
+	if err != nil {
+		t.Fatalf("unable to write manifest : %v", err)
+	}
+	return filename, m
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	content := "{\"event_ref\":\"ref-1\"}\n{\"event_ref\":\"ref-2\"}\n"
+	before := time.Now()
+	filename, written := writeManifestedFile(t, content, 2)
+
+	sum := sha256.Sum256([]byte(content))
+	if written.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected checksum %x, got %s", sum, written.SHA256)
+	}
+	if written.File != filename || written.Count != 2 || written.GeneratedAt.Before(before) {
+		t.Errorf("expected manifest of %s with 2 events generated after %v, got %+v", filename, before, written)
+	}
+
+	verified, err := VerifyManifest(filename)
+	if err != nil {
+		t.Fatalf("unable to verify manifest : %v", err)
+	}
+	if verified.SHA256 != written.SHA256 || verified.Count != written.Count || verified.File != written.File ||
+		!verified.GeneratedAt.Equal(written.GeneratedAt) {
+		t.Errorf("expected manifest %+v, got %+v", written, verified)
+	}
+}
+
+func TestVerifyManifestOfTamperedFile(t *testing.T) {
+	filename, _ := writeManifestedFile(t, "{\"event_ref\":\"ref-1\"}\n", 1)
+	if err := os.WriteFile(filename, []byte("{\"event_ref\":\"ref-2\"}\n"), 0644); err != nil {
+		t.Fatalf("unable to tamper file : %v", err)
+	}
+
+	if _, err := VerifyManifest(filename); !errors.Is(err, ErrBadInput) {
+		t.Errorf("expected bad input error, got %v", err)
+	}
+}
+
+func TestVerifyManifestOfTruncatedFile(t *testing.T) {
+	filename, _ := writeManifestedFile(t, "{\"event_ref\":\"ref-1\"}\n{\"event_ref\":\"ref-2\"}\n", 2)
+	if err := os.Truncate(filename, 10); err != nil {
+		t.Fatalf("unable to truncate file : %v", err)
+	}
+
+	if _, err := VerifyManifest(filename); !errors.Is(err, ErrBadInput) {
+		t.Errorf("expected bad input error, got %v", err)
+	}
+}
+
+func TestVerifyMalformedManifest(t *testing.T) {
+	filename, _ := writeManifestedFile(t, "\n", 0)
+	if err := os.WriteFile(ManifestName(filename), []byte("sha256"), 0644); err != nil {
+		t.Fatalf("unable to write manifest : %v", err)
+	}
+
+	if _, err := VerifyManifest(filename); !errors.Is(err, ErrBadInput) {
+		t.Errorf("expected bad input error, got %v", err)
+	}
+}
+
+func TestVerifyMissingManifest(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.ndjson")
+	if err := os.WriteFile(filename, []byte("\n"), 0644); err != nil {
+		t.Fatalf("unable to write file : %v", err)
+	}
+
+	if _, err := VerifyManifest(filename); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math"
+	"math/bits"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// maskRounds is number of rounds of Feistel network permuting numbers.
+const maskRounds = 4
+
+// Mask returns copies of events with calling and called numbers replaced by pseudo numbers, the rest of the fields are kept.
+//
+// Numbers are permuted by Feistel network with HMAC-SHA256 round function keyed by salt, so the same number is always
+// replaced with the same pseudo number, distinct numbers never share a pseudo number and call graph is preserved,
+// while original numbers can't be restored without the salt. Numbers within range of generated numbers stay within it.
+func Mask(events model.Events, salt string) model.Events {
+	m := newNumberMasker(salt)
+	masked := model.CloneAll(events)
+	for _, e := range masked {
+		e.CallingNumber = m.Mask(e.CallingNumber)
+		e.CalledNumber = m.Mask(e.CalledNumber)
+	}
+	return masked
+}
+
+// numberMasker permutes numbers keeping them within their range.
+type numberMasker struct {
+	mac hash.Hash
+	buf [9]byte
+}
+
+// newNumberMasker creates masker keyed by provided salt.
+func newNumberMasker(salt string) *numberMasker {
+	return &numberMasker{mac: hmac.New(sha256.New, []byte(salt))}
+}
+
+// Mask returns pseudo number of the number. Numbers of generated range [0, NumberMax) are permuted within it,
+// negative and larger numbers of external dumps are permuted among negative and larger ones respectively.
+func (m *numberMasker) Mask(number int) int {
+	bound := int(intBound(NumberMax))
+	switch {
+	case number < 0:
+		return math.MinInt + int(m.permute(uint64(number-math.MinInt), 1<<63))
+	case number < bound:
+		return int(m.permute(uint64(number), uint64(bound)))
+	}
+	return bound + int(m.permute(uint64(number-bound), uint64(math.MaxInt-bound)+1))
+}
+
+// permute returns pseudo value of x within [0, n). Feistel network permutes the smallest domain of even number
+// of bits holding n values, values out of [0, n) are permuted again until they are within it, so the result
+// is a permutation of [0, n) as well.
+func (m *numberMasker) permute(x, n uint64) uint64 {
+	half := (bits.Len64(n-1) + 1) / 2
+	if half == 0 {
+		return x
+	}
+
+	for {
+		x = m.feistel(x, half)
+		if x < n {
+			return x
+		}
+	}
+}
+
+// feistel returns value of x permuted by Feistel network of two halves of provided number of bits.
+func (m *numberMasker) feistel(x uint64, half int) uint64 {
+	mask := uint64(1)<<half - 1
+	left, right := x>>half, x&mask
+	for round := 0; round < maskRounds; round++ {
+		left, right = right, left^(m.round(round, right)&mask)
+	}
+	return left<<half | right
+}
+
+// round returns value of round function of the half.
+func (m *numberMasker) round(round int, half uint64) uint64 {
+	m.buf[0] = byte(round)
+	binary.BigEndian.PutUint64(m.buf[1:], half)
+
+	m.mac.Reset()
+	m.mac.Write(m.buf[:])
+	return binary.BigEndian.Uint64(m.mac.Sum(nil)[:8])
+}
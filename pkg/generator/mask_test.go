@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestMaskIsConsistent(t *testing.T) {
+	events := model.Events{
+		NewEvent(WithRef("ref-1")),
+		NewEvent(WithRef("ref-2")),
+	}
+	events[0].CallingNumber, events[0].CalledNumber = 79001234567, 79007654321
+	// numbers are swapped, so call graph is reversed
+	events[1].CallingNumber, events[1].CalledNumber = 79007654321, 79001234567
+
+	masked := Mask(events, "salt")
+	again := Mask(events, "salt")
+
+	if masked[0].CallingNumber != masked[1].CalledNumber || masked[0].CalledNumber != masked[1].CallingNumber {
+		t.Errorf("expected the same numbers to be masked the same way, got %+v and %+v", masked[0], masked[1])
+	}
+	for i := range masked {
+		if !sameEvent(masked[i], again[i]) {
+			t.Errorf("event %d : expected masking to be deterministic, got %+v and %+v", i, masked[i], again[i])
+		}
+		if masked[i].CallingNumber == events[i].CallingNumber || masked[i].CalledNumber == events[i].CalledNumber {
+			t.Errorf("event %d : expected numbers to be masked, got %+v", i, masked[i])
+		}
+
+		// the rest of the fields are kept
+		expected := *events[i]
+		expected.CallingNumber, expected.CalledNumber = masked[i].CallingNumber, masked[i].CalledNumber
+		if !sameEvent(&expected, masked[i]) {
+			t.Errorf("event %d : expected %+v, got %+v", i, expected, masked[i])
+		}
+	}
+
+	if other := Mask(events, "other salt"); other[0].CallingNumber == masked[0].CallingNumber {
+		t.Errorf("expected different salt to give different pseudo number, got %d", other[0].CallingNumber)
+	}
+}
+
+func TestMaskIsPermutationOfNumbers(t *testing.T) {
+	defer func(max int64) { NumberMax = max }(NumberMax)
+	// range is not a power of 2, so values are permuted again until they are within it
+	NumberMax = 1000
+
+	m := newNumberMasker("salt")
+	seen := map[int]int{}
+	for number := 0; number < int(NumberMax); number++ {
+		masked := m.Mask(number)
+		if masked < 0 || masked >= int(NumberMax) {
+			t.Fatalf("%d : expected pseudo number within [0, %d), got %d", number, NumberMax, masked)
+		}
+		if prev, ok := seen[masked]; ok {
+			t.Fatalf("%d and %d have the same pseudo number %d", prev, number, masked)
+		}
+		seen[masked] = number
+	}
+}
+
+func TestMaskKeepsNumbersOutOfRangeOutOfIt(t *testing.T) {
+	defer func(max int64) { NumberMax = max }(NumberMax)
+	NumberMax = 1000
+
+	m := newNumberMasker("salt")
+	for _, number := range []int{-1, math.MinInt, 1000, 123456789, math.MaxInt} {
+		masked := m.Mask(number)
+		if (number < 0) != (masked < 0) || (number >= 1000) != (masked >= 1000) {
+			t.Errorf("%d : expected pseudo number out of range too, got %d", number, masked)
+		}
+		if m.Mask(number) != masked {
+			t.Errorf("%d : expected masking to be deterministic", number)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MonthCount is number of events to generate within a month.
+type MonthCount struct {
+	// Month is the first instant of the month in Location.
+	Month time.Time
+	// Count is number of events of the month.
+	Count int
+}
+
+// ParseMonthCounts parses number of events per month like 2020-01:100000,2020-02:50000, months are in Location.
+func ParseMonthCounts(s string) ([]MonthCount, error) {
+	var months []MonthCount
+	seen := map[time.Time]bool{}
+	for _, part := range strings.Split(s, ",") {
+		month, count, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, invalidArgs("invalid month count '%s', month:count like 2020-01:100000 expected", part)
+		}
+
+		start, err := time.ParseInLocation("2006-01", strings.TrimSpace(month), Location)
+		if err != nil {
+			return nil, invalidArgs("invalid month '%s', YYYY-MM expected", month)
+		}
+		if seen[start] {
+			return nil, invalidArgs("duplicated month '%s'", month)
+		}
+		seen[start] = true
+
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || n < 0 {
+			return nil, invalidArgs("invalid count '%s' of month %s, non-negative number expected", count, month)
+		}
+		months = append(months, MonthCount{Month: start, Count: n})
+	}
+	return months, nil
+}
+
+// TotalCount returns number of events of all the months.
+func TotalCount(months []MonthCount) int {
+	total := 0
+	for _, m := range months {
+		total += m.Count
+	}
+	return total
+}
+
+// MonthOf returns dates window of the month i-th event belongs to, events fill months in order.
+func MonthOf(months []MonthCount, i int) (since, until time.Time) {
+	for _, m := range months {
+		if i < m.Count {
+			return m.Month, m.Month.AddDate(0, 1, 0)
+		}
+		i -= m.Count
+	}
+	return time.Time{}, time.Time{}
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestParseMonthCounts(t *testing.T) {
+	months, err := ParseMonthCounts("2020-01:100000, 2020-02 : 50000,2019-12:0")
+	if err != nil {
+		t.Fatalf("unable to parse month counts : %v", err)
+	}
+
+	expected := []MonthCount{
+		{Month: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Count: 100000},
+		{Month: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), Count: 50000},
+		{Month: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), Count: 0},
+	}
+	if len(months) != len(expected) {
+		t.Fatalf("expected %d months, got %d", len(expected), len(months))
+	}
+	for i, m := range expected {
+		if !months[i].Month.Equal(m.Month) || months[i].Count != m.Count {
+			t.Errorf("month %d : expected %+v, got %+v", i, m, months[i])
+		}
+	}
+	if total := TotalCount(months); total != 150000 {
+		t.Errorf("expected 150000 events in total, got %d", total)
+	}
+}
+
+func TestParseMonthCountsRejectsInvalid(t *testing.T) {
+	for _, s := range []string{"", "2020-01", "2020-13:100", "2020-1-1:100", "01-2020:100", "2020-01:-1", "2020-01:many", "2020-01:1,2020-01:2"} {
+		if _, err := ParseMonthCounts(s); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("'%s' : expected invalid arguments error, got %v", s, err)
+		}
+	}
+}
+
+func TestPerMonthBucketCounts(t *testing.T) {
+	months, err := ParseMonthCounts("2020-01:20000,2020-02:10000,2020-03:0,2020-04:1")
+	if err != nil {
+		t.Fatalf("unable to parse month counts : %v", err)
+	}
+
+	opts := Options{Dates: &PerMonthDateGen{Months: months}}
+	buckets := map[string]model.Events{}
+	for i := 0; i < TotalCount(months); i++ {
+		e := RandomEvent(opts)
+		month := e.EventDate.Format("2006-01")
+		buckets[month] = append(buckets[month], e)
+	}
+
+	for _, m := range months {
+		month := m.Month.Format("2006-01")
+		if len(buckets[month]) != m.Count {
+			t.Errorf("%s : expected %d events, got %d", month, m.Count, len(buckets[month]))
+		}
+	}
+	if len(buckets) != 3 {
+		t.Errorf("expected events of 3 months only, got %d months", len(buckets))
+	}
+
+	// every large enough bucket follows distribution of event types
+	expected := DefaultDistribution().Percentages()
+	for _, month := range []string{"2020-01", "2020-02"} {
+		counts := buckets[month].CountByType()
+		for eventType, percentage := range expected {
+			actual := float64(counts[eventType]) / float64(len(buckets[month])) * 100
+			if math.Abs(actual-percentage) > 2 {
+				t.Errorf("%s : expected %.2f%% of type %d, got %.2f%%", month, percentage, int(eventType), actual)
+			}
+		}
+	}
+}
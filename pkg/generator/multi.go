@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// multiSink fans events out to all the wrapped sinks.
+type multiSink struct {
+	sinks []Sink
+}
+
+// MultiSink returns sink writing every event to all provided sinks in order, e.g. to a file and to kafka at once.
+// Write stops at the first failed sink, while Flush and Close are called on all the sinks and their errors are aggregated.
+func MultiSink(sinks ...Sink) Sink {
+	return &multiSink{sinks: sinks}
+}
+
+// Write will write event to every sink.
+func (s *multiSink) Write(e *model.Event) error {
+	for _, sink := range s.sinks {
+		if err := sink.Write(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush will flush every sink which buffers events.
+func (s *multiSink) Flush() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		if flusher, ok := sink.(Flusher); ok {
+			if err := flusher.Flush(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return joinErrors(errs)
+}
+
+// Close will close every sink even if some of them fail.
+func (s *multiSink) Close() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// SetProjection will set projection of every sink supporting it.
+func (s *multiSink) SetProjection(p *model.Projection) {
+	for _, sink := range s.sinks {
+		if projected, ok := sink.(interface{ SetProjection(p *model.Projection) }); ok {
+			projected.SetProjection(p)
+		}
+	}
+}
+
+// joinErrors returns single error describing all provided errors, the first one is wrapped.
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	messages := make([]string, 0, len(errs)-1)
+	for _, err := range errs[1:] {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Errorf("%w, %s", errs[0], strings.Join(messages, ", "))
+}
@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// failingSink is memory sink failing writes and close with provided errors.
+type failingSink struct {
+	MemorySink
+	writeErr, closeErr error
+}
+
+// Write will fail with write error if it's set.
+func (s *failingSink) Write(e *model.Event) error {
+	if s.writeErr != nil {
+		return s.writeErr
+	}
+	return s.MemorySink.Write(e)
+}
+
+// Close will mark the sink closed and fail with close error if it's set.
+func (s *failingSink) Close() error {
+	s.MemorySink.Close()
+	return s.closeErr
+}
+
+func TestMultiSinkWritesToMemorySinks(t *testing.T) {
+	events := model.Events{NewEvent(WithRef("ref-1")), NewEvent(WithRef("ref-2")), NewEvent(WithRef("ref-3"))}
+
+	first, second := &MemorySink{}, &MemorySink{}
+	writeEvents(t, MultiSink(first, second), events)
+
+	for _, s := range []*MemorySink{first, second} {
+		assertEvents(t, events, s.Events)
+		if !s.Closed {
+			t.Error("expected sink to be closed")
+		}
+	}
+}
+
+func TestMultiSinkStopsWriteAtFailedSink(t *testing.T) {
+	errWrite := errors.New("write failed")
+	first, failed, last := &MemorySink{}, &failingSink{writeErr: errWrite}, &MemorySink{}
+	s := MultiSink(first, failed, last)
+
+	if err := s.Write(NewEvent()); !errors.Is(err, errWrite) {
+		t.Fatalf("expected write error, got %v", err)
+	}
+	if len(first.Events) != 1 || len(last.Events) != 0 {
+		t.Errorf("expected event written to sinks before the failed one only, got %d and %d events", len(first.Events), len(last.Events))
+	}
+}
+
+func TestMultiSinkClosesAllSinks(t *testing.T) {
+	errFirst, errSecond := errors.New("first close failed"), errors.New("second close failed")
+	first, second, last := &failingSink{closeErr: errFirst}, &failingSink{closeErr: errSecond}, &MemorySink{}
+
+	err := MultiSink(first, second, last).Close()
+	if !errors.Is(err, errFirst) || !strings.Contains(err.Error(), errSecond.Error()) {
+		t.Errorf("expected both close errors, got %v", err)
+	}
+	if !first.Closed || !second.Closed || !last.Closed {
+		t.Errorf("expected all sinks to be closed, got %t, %t and %t", first.Closed, second.Closed, last.Closed)
+	}
+}
@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// areaCodeFactor splits phone number into area code and subscriber number, e.g. 380 1234567.
+const areaCodeFactor = 10000000
+
+// minAreaCode and maxAreaCode are bounds of generated area codes.
+const (
+	minAreaCode = 100
+	maxAreaCode = 999
+)
+
+// NumberWithLocation returns random phone number below NumberMax and location of its area code,
+// so location based rating correlates with caller numbers.
+// Area codes are limited by NumberMax, they start from 0 if it's too small for 3 digit codes.
+func NumberWithLocation() (number int, location string) {
+	bound := intBound(NumberMax)
+
+	maxCode := int64(maxAreaCode)
+	if top := (bound - 1) / areaCodeFactor; top < maxCode {
+		maxCode = top
+	}
+	minCode := int64(minAreaCode)
+	if maxCode < minCode {
+		minCode = maxCode
+	}
+	code := minCode + randInt63n(maxCode-minCode+1)
+
+	// the last area may be cut by the bound
+	subscribers := int64(areaCodeFactor)
+	if rest := bound - code*areaCodeFactor; rest < subscribers {
+		subscribers = rest
+	}
+	return int(code*areaCodeFactor + randInt63n(subscribers)), AreaLocation(int(code))
+}
+
+// AreaCode returns area code of phone number generated by NumberWithLocation.
+func AreaCode(number int) int {
+	return number / areaCodeFactor
+}
+
+// AreaLocation returns location label of area code, the same code always has the same label.
+func AreaLocation(code int) string {
+	return fmt.Sprintf("AREA-%03d", code)
+}
+
+// WithCorrelatedLocation sets calling number and location of the same area.
+func WithCorrelatedLocation() EventOption {
+	return with(func(e *model.Event) {
+		e.CallingNumber, e.Location = NumberWithLocation()
+	})
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRandomNumbersWithinBounds(t *testing.T) {
+	defer func(source, number int64) { SourceMax, NumberMax = source, number }(SourceMax, NumberMax)
+
+	for _, bounds := range []struct{ source, number int64 }{
+		{DefaultNumberMax, DefaultNumberMax},
+		{1000, 10},
+		{1, 1},
+		// larger than int32, so 32-bit truncation would show up
+		{1 << 40, math.MaxInt64},
+	} {
+		SourceMax, NumberMax = bounds.source, bounds.number
+		for i := 0; i < 1000; i++ {
+			e := RandomEvent(Options{})
+			if e.EventSource < 0 || int64(e.EventSource) >= intBound(SourceMax) {
+				t.Fatalf("expected event source within [0, %d), got %d", SourceMax, e.EventSource)
+			}
+			for _, number := range []int{e.CallingNumber, e.CalledNumber} {
+				if number < 0 || int64(number) >= intBound(NumberMax) {
+					t.Fatalf("expected number within [0, %d), got %d", NumberMax, number)
+				}
+			}
+		}
+	}
+}
+
+func TestNumberWithLocationWithinBounds(t *testing.T) {
+	defer func(max int64) { NumberMax = max }(NumberMax)
+
+	for _, test := range []struct {
+		max              int64
+		minCode, maxCode int
+	}{
+		{max: DefaultNumberMax, minCode: minAreaCode, maxCode: maxAreaCode},
+		// the last area is cut by the bound
+		{max: 5*areaCodeFactor*100 + 5, minCode: minAreaCode, maxCode: 500},
+		// too small for 3 digit area codes
+		{max: 50 * areaCodeFactor, minCode: 49, maxCode: 49},
+		{max: 1000, minCode: 0, maxCode: 0},
+		{max: 1, minCode: 0, maxCode: 0},
+	} {
+		NumberMax = test.max
+		for i := 0; i < 1000; i++ {
+			number, location := NumberWithLocation()
+			if number < 0 || int64(number) >= test.max {
+				t.Fatalf("max %d : expected number within [0, %d), got %d", test.max, test.max, number)
+			}
+			code := AreaCode(number)
+			if code < test.minCode || code > test.maxCode {
+				t.Fatalf("max %d : expected area code within [%d, %d], got %d", test.max, test.minCode, test.maxCode, code)
+			}
+			if location != AreaLocation(code) {
+				t.Fatalf("max %d : expected location %s of number %d, got %s", test.max, AreaLocation(code), number, location)
+			}
+		}
+	}
+}
+
+func TestCorrelatedLocation(t *testing.T) {
+	codes := map[string]int{}
+	locations := map[int]string{}
+	for i := 0; i < 10000; i++ {
+		e := RandomEvent(Options{Events: []EventOption{WithCorrelatedLocation()}})
+		code := AreaCode(e.CallingNumber)
+
+		// location determines area code of caller and vice versa
+		if prev, ok := codes[e.Location]; ok && prev != code {
+			t.Fatalf("expected callers of %s to share area code %d, got %d", e.Location, prev, code)
+		}
+		if prev, ok := locations[code]; ok && prev != e.Location {
+			t.Fatalf("expected area code %d to have location %s, got %s", code, prev, e.Location)
+		}
+		codes[e.Location] = code
+		locations[code] = e.Location
+	}
+
+	// 10000 events out of 900 area codes cover nearly all of them
+	if len(codes) < 800 {
+		t.Errorf("expected locations of most area codes, got %d", len(codes))
+	}
+}
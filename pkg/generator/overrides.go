@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// FieldOverrides are optional generators of event fields replacing the default random values, nil functions keep defaults.
+// Options passed explicitly to the event, like WithLocation, still take precedence over overrides.
+type FieldOverrides struct {
+	Source        func() int
+	CallingNumber func() int
+	CalledNumber  func() int
+	Location      func() string
+	Attr1         func() string
+	Attr2         func() string
+	Attr3         func() string
+	Attr4         func() string
+	Attr5         func() string
+	Attr6         func() string
+	Attr7         func() string
+	Attr8         func() string
+}
+
+// WithOverrides sets event fields generated by overrides.
+func WithOverrides(o FieldOverrides) EventOption {
+	return with(func(e *model.Event) {
+		if o.Source != nil {
+			e.EventSource = o.Source()
+		}
+		if o.CallingNumber != nil {
+			e.CallingNumber = o.CallingNumber()
+		}
+		if o.CalledNumber != nil {
+			e.CalledNumber = o.CalledNumber()
+		}
+		if o.Location != nil {
+			e.Location = o.Location()
+		}
+
+		attrs := e.Attributes()
+		for i, attr := range [8]func() string{o.Attr1, o.Attr2, o.Attr3, o.Attr4, o.Attr5, o.Attr6, o.Attr7, o.Attr8} {
+			if attr != nil {
+				attrs[i] = attr()
+			}
+		}
+		e.SetAttributes(attrs)
+	})
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRandomEventWithOverrides(t *testing.T) {
+	calls := 0
+	opts := Options{Overrides: FieldOverrides{
+		Source:        func() int { return 42 },
+		CallingNumber: func() int { return 111 },
+		Location: func() string {
+			calls++
+			return fmt.Sprintf("CELL%d", calls)
+		},
+		Attr2: func() string { return "override" },
+		Attr8: func() string { return "" },
+	}}
+
+	for i := 1; i <= 10; i++ {
+		e := RandomEvent(opts)
+		if e.EventSource != 42 || e.CallingNumber != 111 {
+			t.Errorf("expected overridden source 42 and calling number 111, got %d and %d", e.EventSource, e.CallingNumber)
+		}
+		// override is called for every event
+		if expected := fmt.Sprintf("CELL%d", i); e.Location != expected {
+			t.Errorf("expected location %s, got %s", expected, e.Location)
+		}
+		if attrs := e.Attributes(); attrs[1] != "override" || attrs[7] != "" {
+			t.Errorf("expected overridden attributes 2 and 8, got %q", attrs)
+		}
+	}
+}
+
+func TestRandomEventWithoutOverridesKeepsDefaults(t *testing.T) {
+	e := RandomEvent(Options{Overrides: FieldOverrides{Attr1: func() string { return "override" }}})
+	if e.Location == "" {
+		t.Errorf("expected default random location, got %+v", e)
+	}
+	if attr := e.Attributes()[0]; attr != "override" {
+		t.Errorf("expected overridden attribute 1, got %s", attr)
+	}
+}
+
+func TestExplicitOptionsTakePrecedenceOverOverrides(t *testing.T) {
+	e := RandomEvent(Options{
+		Overrides: FieldOverrides{Location: func() string { return "override" }},
+		Events:    []EventOption{WithLocation("explicit")},
+	})
+	if e.Location != "explicit" {
+		t.Errorf("expected explicit location, got %s", e.Location)
+	}
+}
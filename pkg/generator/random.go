@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+// rnd is random generator used by the package, global math/rand source is used when it's nil.
+var rnd *rand.Rand
+
+// SetSource will make the package draw random values from provided source instead of the global
+// math/rand one, passing nil restores the global source.
+//
+// Sources created by rand.NewSource are not safe for concurrent use, so if the package is used
+// from multiple goroutines caller must either guard the calls or use own source per goroutine.
+func SetSource(src rand.Source) {
+	if src == nil {
+		rnd = nil
+		return
+	}
+	rnd = rand.New(src)
+}
+
+// randIntn returns random int in [0,n) from configured source.
+func randIntn(n int) int {
+	if rnd != nil {
+		return rnd.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// randInt31n returns random int32 in [0,n) from configured source.
+func randInt31n(n int32) int32 {
+	if rnd != nil {
+		return rnd.Int31n(n)
+	}
+	return rand.Int31n(n)
+}
+
+// randInt63n returns random int64 in [0,n) from configured source.
+func randInt63n(n int64) int64 {
+	if rnd != nil {
+		return rnd.Int63n(n)
+	}
+	return rand.Int63n(n)
+}
+
+// randNumber returns random non-negative int below max, max is limited by int size of the platform.
+func randNumber(max int64) int {
+	return int(randInt63n(intBound(max)))
+}
+
+// intBound limits provided bound by int size of the platform, so int conversion never overflows.
+func intBound(max int64) int64 {
+	if max > math.MaxInt {
+		return math.MaxInt
+	}
+	return max
+}
+
+// randFloat64 returns random float64 in [0.0,1.0) from configured source.
+func randFloat64() float64 {
+	if rnd != nil {
+		return rnd.Float64()
+	}
+	return rand.Float64()
+}
+
+// randExpFloat64 returns exponentially distributed float64 with mean 1 from configured source.
+func randExpFloat64() float64 {
+	if rnd != nil {
+		return rnd.ExpFloat64()
+	}
+	return rand.ExpFloat64()
+}
+
+// WorkerSeed derives seed of provided worker from the base seed.
+//
+// Naive seed+worker seeding feeds nearly identical seeds to the generator, which may produce correlated
+// streams. Instead worker index and seed are mixed through SplitMix64 finalizer, so seeds of
+// neighbour workers differ in about half of the bits while staying reproducible for the same base seed.
+func WorkerSeed(seed int64, worker int) int64 {
+	return int64(splitMix64(uint64(seed) ^ splitMix64(uint64(worker)+1)))
+}
+
+// NewWorkerRand creates random generator of provided worker, see WorkerSeed.
+// Every worker must use its own generator, they are not safe for concurrent use.
+func NewWorkerRand(seed int64, worker int) *rand.Rand {
+	return rand.New(rand.NewSource(WorkerSeed(seed, worker)))
+}
+
+// splitMix64 is a finalizer of SplitMix64 generator, it's a bijection with a good avalanche effect.
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
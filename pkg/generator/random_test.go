@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"math"
+	"testing"
+)
+
+// workerDurations returns durations of events generated by the worker.
+func workerDurations(seed int64, worker, n int) []float64 {
+	SetSource(NewWorkerRand(seed, worker))
+	durations := make([]float64, n)
+	for i := range durations {
+		durations[i] = float64(RandomEvent(Options{}).DurationSeconds)
+	}
+	return durations
+}
+
+// correlation returns Pearson correlation coefficient of samples of the same length.
+func correlation(x, y []float64) float64 {
+	var sumX, sumY float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+	}
+	meanX, meanY := sumX/float64(len(x)), sumY/float64(len(y))
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+func TestWorkerStreamsAreNotCorrelated(t *testing.T) {
+	defer SetSource(nil)
+	const workers, n = 8, 10000
+
+	for _, seed := range []int64{0, 1, 42} {
+		streams := make([][]float64, workers)
+		for w := range streams {
+			streams[w] = workerDurations(seed, w, n)
+		}
+
+		// correlation of independent samples is within 5 standard errors of zero
+		limit := 5 / math.Sqrt(n)
+		for i := 0; i < workers; i++ {
+			for j := i + 1; j < workers; j++ {
+				if r := correlation(streams[i], streams[j]); math.Abs(r) > limit {
+					t.Errorf("seed %d : expected no correlation of workers %d and %d, got %.4f", seed, i, j, r)
+				}
+			}
+		}
+	}
+}
+
+func TestWorkerStreamsAreReproducible(t *testing.T) {
+	defer SetSource(nil)
+
+	first := workerDurations(42, 3, 100)
+	second := workerDurations(42, 3, 100)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("duration %d : expected %v of the same seed and worker, got %v", i, first[i], second[i])
+		}
+	}
+}
+
+func TestWorkerSeed(t *testing.T) {
+	seeds := map[int64]int{}
+	for w := 0; w < 1000; w++ {
+		seed := WorkerSeed(42, w)
+		if other, ok := seeds[seed]; ok {
+			t.Fatalf("expected distinct seeds, workers %d and %d share %d", other, w, seed)
+		}
+		seeds[seed] = w
+
+		if again := WorkerSeed(42, w); again != seed {
+			t.Errorf("worker %d : expected the same seed %d, got %d", w, seed, again)
+		}
+	}
+
+	// seed of the worker depends on the base seed
+	if WorkerSeed(1, 0) == WorkerSeed(2, 0) {
+		t.Error("expected different seeds of different base seeds")
+	}
+	if WorkerSeed(1, 1) == WorkerSeed(2, 0) {
+		t.Error("expected seeds not to be shifted base seeds")
+	}
+}
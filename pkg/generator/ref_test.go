@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// benchmarkRefs is number of refs generated by every iteration of ref benchmarks.
+const benchmarkRefs = 1000000
+
+// withFastRefs runs fn with buffered random source of refs and restores the default one.
+func withFastRefs(fn func()) {
+	EnableFastRefs()
+	defer uuid.DisableRandPool()
+	fn()
+}
+
+// assertV4Refs fails the test unless provided number of refs are unique version 4 UUIDs.
+func assertV4Refs(t *testing.T, g *RefGenerator, n int) {
+	t.Helper()
+	seen := make(map[string]struct{}, n)
+	for i := 0; i < n; i++ {
+		ref := g.Next()
+		id, err := uuid.Parse(ref)
+		if err != nil {
+			t.Fatalf("expected UUID, got %s : %v", ref, err)
+		}
+		if id.Version() != 4 || id.Variant() != uuid.RFC4122 {
+			t.Fatalf("expected RFC 4122 version 4 UUID, got %s of version %d", ref, id.Version())
+		}
+		if _, ok := seen[ref]; ok {
+			t.Fatalf("ref %s is duplicated after %d refs", ref, i)
+		}
+		seen[ref] = struct{}{}
+	}
+}
+
+func TestRefsAreUniqueV4(t *testing.T) {
+	g, err := NewRefGenerator(4, false)
+	if err != nil {
+		t.Fatalf("unable to create ref generator : %v", err)
+	}
+	assertV4Refs(t, g, 10000)
+}
+
+func TestFastRefsAreUniqueV4(t *testing.T) {
+	g, err := NewRefGenerator(4, false)
+	if err != nil {
+		t.Fatalf("unable to create ref generator : %v", err)
+	}
+	withFastRefs(func() { assertV4Refs(t, g, 10000) })
+}
+
+func TestV7RefsAreMonotonic(t *testing.T) {
+	g, err := NewRefGenerator(7, true)
+	if err != nil {
+		t.Fatalf("unable to create ref generator : %v", err)
+	}
+
+	start := time.Now().Add(-time.Millisecond)
+	prev := ""
+	for i := 0; i < 10000; i++ {
+		ref := g.Next()
+		id, err := uuid.Parse(ref)
+		if err != nil {
+			t.Fatalf("expected UUID, got %s : %v", ref, err)
+		}
+		if id.Version() != 7 || id.Variant() != uuid.RFC4122 {
+			t.Fatalf("expected RFC 4122 version 7 UUID, got %s of version %d", ref, id.Version())
+		}
+		// refs of a single run sort in generation order, so index is appended to
+		if ref <= prev {
+			t.Fatalf("ref %d : expected %s to sort after %s", i, ref, prev)
+		}
+		prev = ref
+	}
+
+	sec, nsec := uuid.Must(uuid.Parse(prev)).Time().UnixTime()
+	if created := time.Unix(sec, nsec); created.Before(start) || created.After(time.Now().Add(time.Millisecond)) {
+		t.Errorf("expected ref created since %v, got %v", start, created)
+	}
+}
+
+// benchmarkRefGenerator generates benchmarkRefs refs per iteration.
+func benchmarkRefGenerator(b *testing.B) {
+	g, err := NewRefGenerator(4, false)
+	if err != nil {
+		b.Fatalf("unable to create ref generator : %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < benchmarkRefs; j++ {
+			g.Next()
+		}
+	}
+}
+
+func BenchmarkRefs(b *testing.B) {
+	benchmarkRefGenerator(b)
+}
+
+func BenchmarkFastRefs(b *testing.B) {
+	withFastRefs(func() { benchmarkRefGenerator(b) })
+}
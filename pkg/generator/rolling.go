@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// NumberedName returns name of i-th part of the file, e.g. events.0001.ndjson for events.ndjson.
+func NumberedName(filename string, i int) string {
+	ext := filepath.Ext(filename)
+	return fmt.Sprintf("%s.%04d%s", strings.TrimSuffix(filename, ext), i, ext)
+}
+
+// RollingFileSink writes events to numbered files, switching to the next file
+// once the current one reaches the size limit.
+type RollingFileSink struct {
+	filename   string
+	format     string
+	perm       os.FileMode
+	maxSize    int64
+	projection *model.Projection
+
+	// current is file being written, nil if it's already full.
+	current *FileSink
+	// files are names of all the created files.
+	files []string
+}
+
+// NewRollingFileSink creates sink writing events to files named after filename like events.0001.ndjson.
+// Only streaming formats are supported, as json array can't be split without buffering the whole file.
+func NewRollingFileSink(filename, format string, maxSize int64, perm os.FileMode) (*RollingFileSink, error) {
+	if format != FormatNDJSON && format != FormatCSV {
+		return nil, invalidArgs("rolling output is not supported for %s format, use %s or %s", format, FormatNDJSON, FormatCSV)
+	}
+	if maxSize <= 0 {
+		return nil, invalidArgs("max file size must be positive, got %d", maxSize)
+	}
+
+	s := &RollingFileSink{filename: filename, format: format, perm: perm, maxSize: maxSize}
+	if err := s.roll(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetProjection makes sink write only fields selected by projection, it must be set before the first write.
+func (s *RollingFileSink) SetProjection(p *model.Projection) {
+	s.projection = p
+	s.current.SetProjection(p)
+}
+
+// Files returns names of all the files created so far.
+func (s *RollingFileSink) Files() []string {
+	return s.files
+}
+
+// Write will write a single event, the next file is started if the current one is full.
+func (s *RollingFileSink) Write(e *model.Event) error {
+	if s.current == nil {
+		if err := s.roll(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.current.Write(e); err != nil {
+		return err
+	}
+
+	if s.current.Size() >= s.maxSize {
+		err := s.current.Close()
+		s.current = nil
+		return err
+	}
+	return nil
+}
+
+// Flush will push buffered events of the current file.
+func (s *RollingFileSink) Flush() error {
+	if s.current == nil {
+		return nil
+	}
+	return s.current.Flush()
+}
+
+// Close will close the current file.
+func (s *RollingFileSink) Close() error {
+	if s.current == nil {
+		return nil
+	}
+	return s.current.Close()
+}
+
+// roll will open the next numbered file.
+func (s *RollingFileSink) roll() error {
+	name := NumberedName(s.filename, len(s.files)+1)
+
+	current, err := NewFileSink(name, s.format, false, s.perm)
+	if err != nil {
+		return err
+	}
+	current.SetProjection(s.projection)
+
+	s.current = current
+	s.files = append(s.files, name)
+	return nil
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestRollingFileSink(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "events.ndjson")
+
+	// every event exceeds the limit, so every event gets its own file
+	s, err := NewRollingFileSink(filename, FormatNDJSON, 1, 0644)
+	if err != nil {
+		t.Fatalf("unable to create sink : %v", err)
+	}
+
+	events := model.Events{NewEvent(WithRef("ref-1")), NewEvent(WithRef("ref-2"))}
+	for _, e := range events {
+		if err := s.Write(e); err != nil {
+			t.Fatalf("unable to write event : %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("unable to close sink : %v", err)
+	}
+
+	expected := []string{filepath.Join(dir, "events.0001.ndjson"), filepath.Join(dir, "events.0002.ndjson")}
+	if !reflect.DeepEqual(s.Files(), expected) {
+		t.Fatalf("expected files %v, got %v", expected, s.Files())
+	}
+
+	for i, name := range expected {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatalf("unable to open %s : %v", name, err)
+		}
+		read, err := ReadNDJSON(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("unable to read %s : %v", name, err)
+		}
+		assertEvents(t, events[i:i+1], read)
+	}
+}
+
+func TestRollingFileSinkKeepsEventsBelowLimit(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.csv")
+
+	s, err := NewRollingFileSink(filename, FormatCSV, 1<<20, 0644)
+	if err != nil {
+		t.Fatalf("unable to create sink : %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := s.Write(NewEvent()); err != nil {
+			t.Fatalf("unable to write event : %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("unable to close sink : %v", err)
+	}
+
+	if len(s.Files()) != 1 || s.Files()[0] != NumberedName(filename, 1) {
+		t.Fatalf("expected single file %s, got %v", NumberedName(filename, 1), s.Files())
+	}
+}
+
+func TestRollingFileSinkRejectsJSON(t *testing.T) {
+	_, err := NewRollingFileSink(filepath.Join(t.TempDir(), "events.json"), FormatJSON, 1, 0644)
+	if err == nil {
+		t.Fatal("expected json array to be rejected")
+	}
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// Sessions generates events some of which are split into multi-part sessions, like a long data session
+// reported by partial records.
+//
+// Parts of a session share source, type, calling/called numbers and location, follow each other in time
+// and carry ref of the first part as session id in Attr1 and sequence number starting from 1 in Attr2,
+// so the session could be reconstructed from the attributes.
+type Sessions struct {
+	fraction float64
+	maxParts int
+
+	// first is the first part of the current session, nil if no session is in progress.
+	first *model.Event
+	last  *model.Event
+	part  int
+	parts int
+}
+
+// NewSessions creates session generator, fraction is the probability of a new event to start a session
+// of 2 to maxParts parts.
+func NewSessions(fraction float64, maxParts int) (*Sessions, error) {
+	if fraction < 0 || fraction > 1 {
+		return nil, invalidArgs("session fraction %v, value from 0.0 to 1.0 expected", fraction)
+	}
+	if maxParts < 2 {
+		return nil, invalidArgs("max session parts %d, at least 2 expected", maxParts)
+	}
+	return &Sessions{fraction: fraction, maxParts: maxParts}, nil
+}
+
+// Next returns the next part of the current session or a new event created with provided options.
+func (s *Sessions) Next(opts ...EventOption) *model.Event {
+	if s.first != nil {
+		return s.nextPart(opts)
+	}
+
+	e := NewEvent(opts...)
+	if randFloat64() < s.fraction {
+		s.first, s.last = e, e
+		s.part, s.parts = 1, 2+randIntn(s.maxParts-1)
+		e.Attr1, e.Attr2 = e.EventRef, "1"
+	}
+	return e
+}
+
+// nextPart creates the next part of the current session, the session is finished with its last part.
+func (s *Sessions) nextPart(opts []EventOption) *model.Event {
+	first := s.first
+	e := NewEvent(append(opts,
+		WithSource(first.EventSource),
+		WithType(first.EventType),
+		WithNumbers(first.CallingNumber, first.CalledNumber),
+		WithLocation(first.Location),
+		WithDate(s.last.EventDate.Add(time.Duration(s.last.DurationSeconds)*time.Second)),
+	)...)
+
+	s.part++
+	e.Attr1, e.Attr2 = first.EventRef, strconv.Itoa(s.part)
+
+	s.last = e
+	if s.part == s.parts {
+		s.first, s.last = nil, nil
+	}
+	return e
+}
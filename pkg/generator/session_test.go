@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestSessionsReconstructedFromAttributes(t *testing.T) {
+	const n, fraction, maxParts = 10000, 0.3, 4
+	s, err := NewSessions(fraction, maxParts)
+	if err != nil {
+		t.Fatalf("unable to create sessions : %v", err)
+	}
+
+	events := make(model.Events, 0, n)
+	// refs are indexes of events by their refs
+	refs := map[string]int{}
+	// the last session is finished, so every session is complete
+	for i := 0; i < n || s.first != nil; i++ {
+		e := RandomEvent(Options{Sessions: s})
+		events = append(events, e)
+		refs[e.EventRef] = i
+	}
+
+	// sessions are parts of events grouped by session id, regular events have random attributes not matching any ref
+	sessions := map[string]model.Events{}
+	var order []string
+	for i, e := range events {
+		first, ok := refs[e.Attr1]
+		if !ok {
+			continue
+		}
+		// session id is ref of the first part, so it points at an earlier event or the event itself
+		if first > i {
+			t.Fatalf("event %d : session id points at later event %d", i, first)
+		}
+		if _, ok := sessions[e.Attr1]; !ok {
+			order = append(order, e.Attr1)
+		}
+		sessions[e.Attr1] = append(sessions[e.Attr1], e)
+	}
+
+	starts := 0
+	for _, id := range order {
+		parts := sessions[id]
+		if len(parts) < 2 || len(parts) > maxParts {
+			t.Errorf("session %s : expected 2 to %d parts, got %d", id, maxParts, len(parts))
+		}
+		first := parts[0]
+		if first.EventRef != id {
+			t.Errorf("session %s : expected the first part to be session id, got %s", id, first.EventRef)
+		}
+		for i, part := range parts {
+			if part.Attr2 != strconv.Itoa(i+1) {
+				t.Errorf("session %s : expected sequence number %d, got %s", id, i+1, part.Attr2)
+			}
+			if part.EventSource != first.EventSource || part.EventType != first.EventType || part.Location != first.Location ||
+				part.CallingNumber != first.CallingNumber || part.CalledNumber != first.CalledNumber {
+				t.Errorf("session %s : part %d differs from the first one : %+v, %+v", id, i+1, part, first)
+			}
+			if i > 0 {
+				prev := parts[i-1]
+				if end := prev.EventDate.Add(time.Duration(prev.DurationSeconds) * time.Second); !part.EventDate.Equal(end) {
+					t.Errorf("session %s : expected part %d to start at %v, got %v", id, i+1, end, part.EventDate)
+				}
+			}
+		}
+		starts++
+	}
+
+	// every event not continuing a session could start one
+	continuations := 0
+	for _, parts := range sessions {
+		continuations += len(parts) - 1
+	}
+	if actual := float64(starts) / float64(len(events)-continuations); math.Abs(actual-fraction) > 0.03 {
+		t.Errorf("expected %.2f of events to start session, got %.4f", fraction, actual)
+	}
+}
+
+func TestNewSessionsRejectsInvalidArgs(t *testing.T) {
+	tests := []struct {
+		fraction float64
+		maxParts int
+	}{
+		{fraction: -0.1, maxParts: 2},
+		{fraction: 1.1, maxParts: 2},
+		{fraction: 0.5, maxParts: 1},
+	}
+	for _, test := range tests {
+		if _, err := NewSessions(test.fraction, test.maxParts); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("fraction %v and %d parts : expected invalid arguments error, got %v", test.fraction, test.maxParts, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"math"
+	"time"
+)
+
+// supported traffic shapes, they define how event dates are spread over the dates window.
+const (
+	// ShapeUniform spreads events evenly, hour of day follows HourWeights.
+	ShapeUniform = "uniform"
+	// ShapeBursty concentrates most of the events in short random spikes.
+	ShapeBursty = "bursty"
+	// ShapeDiurnal follows day/night sine wave with peak at noon and trough at midnight.
+	ShapeDiurnal = "diurnal"
+)
+
+// burst parameters of bursty traffic shape.
+const (
+	// burstCount is number of spikes within the dates window.
+	burstCount = 24
+	// burstShare is share of events happening within spikes, the rest are spread uniformly.
+	burstShare = 0.9
+	// burstSpread is mean distance of event from the spike start.
+	burstSpread = 15 * time.Minute
+)
+
+// bursts are starts of the spikes of bursty traffic shape, they are drawn on the first use so they follow the seed.
+var bursts struct {
+	enabled bool
+	starts  []time.Time
+}
+
+// SetTrafficShape configures how RandomDate spreads event dates over the dates window.
+// Diurnal shape replaces HourWeights, so it can't be combined with custom peak hours.
+func SetTrafficShape(shape string) error {
+	bursts.enabled, bursts.starts = false, nil
+
+	switch shape {
+	case ShapeUniform:
+	case ShapeBursty:
+		bursts.enabled = true
+	case ShapeDiurnal:
+		HourWeights = DiurnalHourWeights()
+	default:
+		return invalidArgs("unknown traffic shape '%s', %s, %s or %s expected", shape, ShapeUniform, ShapeBursty, ShapeDiurnal)
+	}
+	return nil
+}
+
+// DiurnalHourWeights returns weights following sine wave with peak at noon, the quietest hour is about 20 times less likely.
+func DiurnalHourWeights() [24]float64 {
+	var weights [24]float64
+	for h := range weights {
+		weights[h] = 1.05 + math.Sin(2*math.Pi*float64(h-6)/24)
+	}
+	return weights
+}
+
+// diurnalHours are weights of diurnal traffic shape.
+var diurnalHours = DiurnalHourWeights()
+
+// BurstyDateGen generates dates of bursty traffic shape between Since and Until,
+// dates are between 2010 and 2020 years if Since is zero.
+type BurstyDateGen struct {
+	Since, Until time.Time
+	// starts are starts of the spikes, they are drawn on the first use.
+	starts []time.Time
+}
+
+// Next returns random date, most of the dates are clustered in spikes.
+func (g *BurstyDateGen) Next() time.Time {
+	since, until := g.Since, g.Until
+	if since.IsZero() {
+		since, until = time.Date(2010, 1, 1, 0, 0, 0, 0, Location), time.Date(2021, 1, 1, 0, 0, 0, 0, Location)
+	}
+	return *burstyDate(since, until, &g.starts)
+}
+
+// DiurnalDateGen generates dates of diurnal traffic shape between Since and Until with hour of day picked
+// according to DiurnalHourWeights. Dates are between 2010 and 2020 years if Since is zero.
+type DiurnalDateGen struct {
+	Since, Until time.Time
+}
+
+// Next returns random date.
+func (g DiurnalDateGen) Next() time.Time {
+	return randomDate(g.Since, g.Until, &diurnalHours)
+}
+
+// burstyDate returns date of bursty traffic within the window from since to until,
+// spike starts are drawn into starts if it's empty.
+func burstyDate(since, until time.Time, starts *[]time.Time) *time.Time {
+	if *starts == nil {
+		for i := 0; i < burstCount; i++ {
+			*starts = append(*starts, *RandomDateBetween(since, until))
+		}
+	}
+
+	if randFloat64() >= burstShare {
+		return RandomDateBetween(since, until)
+	}
+
+	for {
+		start := (*starts)[randIntn(len(*starts))]
+		t := start.Add(time.Duration(randExpFloat64() * float64(burstSpread)))
+		if t.Before(until) {
+			return &t
+		}
+	}
+}
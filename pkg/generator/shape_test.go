@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// withTrafficShape sets traffic shape and dates window for the test.
+func withTrafficShape(t *testing.T, shape string, since, until time.Time) {
+	t.Helper()
+	prevSince, prevUntil, prevWeights := Since, Until, HourWeights
+	t.Cleanup(func() {
+		Since, Until, HourWeights = prevSince, prevUntil, prevWeights
+		SetTrafficShape(ShapeUniform)
+	})
+
+	Since, Until = since, until
+	if err := SetTrafficShape(shape); err != nil {
+		t.Fatalf("unable to set traffic shape : %v", err)
+	}
+}
+
+// dispersion returns variance to mean ratio of numbers of dates per hour of the window drawn from next,
+// it's about 1 for dates spread uniformly and grows as dates cluster.
+func dispersion(t *testing.T, next func() time.Time, since, until time.Time, n int) float64 {
+	t.Helper()
+	counts := make([]float64, int(until.Sub(since).Hours()))
+	for i := 0; i < n; i++ {
+		date := next()
+		if date.Before(since) || !date.Before(until) {
+			t.Fatalf("expected date within [%v, %v), got %v", since, until, date)
+		}
+		counts[int(date.Sub(since).Hours())]++
+	}
+
+	mean := float64(n) / float64(len(counts))
+	variance := 0.0
+	for _, count := range counts {
+		variance += (count - mean) * (count - mean)
+	}
+	return variance / float64(len(counts)) / mean
+}
+
+func TestBurstyShapeIsDenserThanUniform(t *testing.T) {
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+
+	withTrafficShape(t, ShapeUniform, since, until)
+	uniform := dispersion(t, nextRandomDate, since, until, 50000)
+
+	withTrafficShape(t, ShapeBursty, since, until)
+	bursty := dispersion(t, nextRandomDate, since, until, 50000)
+
+	if uniform > 2 {
+		t.Errorf("expected uniform dates to be spread evenly, got dispersion %.2f", uniform)
+	}
+	if bursty < 10*uniform {
+		t.Errorf("expected bursty dates to be clustered, got dispersion %.2f of bursty and %.2f of uniform", bursty, uniform)
+	}
+}
+
+// nextRandomDate returns date drawn by RandomDate.
+func nextRandomDate() time.Time {
+	return *RandomDate()
+}
+
+func TestBurstyDateGenIsDenserThanUniform(t *testing.T) {
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+
+	uniform := dispersion(t, UniformDateGen{Since: since, Until: until}.Next, since, until, 50000)
+	bursty := dispersion(t, (&BurstyDateGen{Since: since, Until: until}).Next, since, until, 50000)
+
+	if bursty < 10*uniform {
+		t.Errorf("expected bursty dates to be clustered, got dispersion %.2f of bursty and %.2f of uniform", bursty, uniform)
+	}
+}
+
+func TestBurstyDateGenWithoutWindow(t *testing.T) {
+	var g BurstyDateGen
+	for i := 0; i < 1000; i++ {
+		if date := g.Next(); date.Year() < 2010 || date.Year() > 2020 {
+			t.Fatalf("expected date between 2010 and 2020 years, got %v", date)
+		}
+	}
+}
+
+func TestDiurnalDateGenPeaksAtNoon(t *testing.T) {
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	g := DiurnalDateGen{Since: since, Until: until}
+
+	var hours [24]int
+	for i := 0; i < 50000; i++ {
+		date := g.Next()
+		if date.Before(since) || !date.Before(until) {
+			t.Fatalf("expected date within [%v, %v), got %v", since, until, date)
+		}
+		hours[date.Hour()]++
+	}
+	if hours[12] < 10*hours[0] {
+		t.Errorf("expected noon to be much busier than midnight, got %d and %d events", hours[12], hours[0])
+	}
+}
+
+func TestDiurnalShapePeaksAtNoon(t *testing.T) {
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	withTrafficShape(t, ShapeDiurnal, since, since.AddDate(0, 0, 7))
+
+	var hours [24]int
+	for i := 0; i < 50000; i++ {
+		hours[RandomDate().Hour()]++
+	}
+	if hours[12] < 10*hours[0] {
+		t.Errorf("expected noon to be much busier than midnight, got %d and %d events", hours[12], hours[0])
+	}
+}
+
+func TestSetTrafficShapeRejectsUnknown(t *testing.T) {
+	if err := SetTrafficShape("spiky"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("expected invalid arguments error, got %v", err)
+	}
+}
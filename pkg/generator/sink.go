@@ -0,0 +1,434 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// Sink is destination of generated events.
+type Sink interface {
+	// Write will send a single event to the sink.
+	Write(e *model.Event) error
+	// Close will flush buffered events and release resources of the sink.
+	Close() error
+}
+
+// DefaultBufferSize is default size of write buffer of sinks.
+const DefaultBufferSize = 1 << 20
+
+// BufferSize is size of write buffer of sinks created afterwards.
+// Small buffer causes many syscalls in streaming formats, so it's tuned by storage.
+var BufferSize = DefaultBufferSize
+
+// Flusher is implemented by sinks buffering events, Flush pushes already written events to destination.
+type Flusher interface {
+	Flush() error
+}
+
+// WriterSink writes events to io.Writer in one of supported formats.
+// Events are written one by one in every format, json array is started by the first event and closed by Close.
+type WriterSink struct {
+	w   *bufio.Writer
+	out *countingWriter
+	enc *json.Encoder
+	cw  *csv.Writer
+
+	// header is set while CSV header is still to be written.
+	header bool
+	// cdr is set for call detail records written with cw.
+	cdr bool
+	// count is number of written events.
+	count int
+	// projection selects written fields, all the fields are written if it's nil.
+	projection *model.Projection
+
+	// arrayOpen is set when json array is already started, so events continue it.
+	arrayOpen bool
+	// arrayElements is set when started json array already has elements.
+	arrayElements bool
+	// indent of json array elements, array is minified if it's empty.
+	indent string
+}
+
+// NewWriterSink creates sink writing events to w in provided format.
+// CSV header is written only if requested, so appended files continue the same table.
+func NewWriterSink(w io.Writer, format string, header bool) (*WriterSink, error) {
+	out := &countingWriter{w: w}
+	s := &WriterSink{w: bufio.NewWriterSize(out, BufferSize), out: out}
+
+	switch format {
+	case FormatJSON:
+	case FormatNDJSON:
+		s.enc = json.NewEncoder(s.w)
+	case FormatCSV:
+		s.cw = csv.NewWriter(s.w)
+		s.header = header
+	case FormatCDR:
+		s.cw = csv.NewWriter(s.w)
+		s.header = header
+		s.cdr = true
+	default:
+		return nil, invalidArgs("unknown format '%s'", format)
+	}
+	return s, nil
+}
+
+// SetProjection makes sink write only fields selected by projection, it must be set before the first write.
+func (s *WriterSink) SetProjection(p *model.Projection) {
+	s.projection = p
+}
+
+// SetIndent makes sink write json array with every event on its own lines indented by provided indent,
+// it's for human inspection as array gets several times larger. Streaming formats are not indented.
+func (s *WriterSink) SetIndent(indent string) {
+	s.indent = indent
+}
+
+// Write will write a single event.
+func (s *WriterSink) Write(e *model.Event) error {
+	s.count++
+
+	switch {
+	case s.enc != nil && s.projection != nil:
+		content, err := s.projection.JSON(e)
+		if err != nil {
+			return err
+		}
+		if _, err := s.w.Write(content); err != nil {
+			return err
+		}
+		return s.w.WriteByte('\n')
+	case s.enc != nil:
+		return s.enc.Encode(e)
+	case s.cw != nil:
+		if err := s.writeHeader(); err != nil {
+			return err
+		}
+		if s.cdr {
+			return s.cw.Write(CDRRecord(e))
+		}
+		if s.projection != nil {
+			return s.cw.Write(s.projection.CSVRecord(e))
+		}
+		return s.cw.Write(e.CSVRecord())
+	}
+	return s.writeElement(e)
+}
+
+// Size returns number of bytes written to the sink including buffered ones.
+func (s *WriterSink) Size() int64 {
+	if s.cw != nil {
+		// csv writer has its own buffer, move it to the counted one
+		s.cw.Flush()
+	}
+	return s.out.n + int64(s.w.Buffered())
+}
+
+// writeHeader will write CSV header if it's not written yet.
+func (s *WriterSink) writeHeader() error {
+	if !s.header {
+		return nil
+	}
+	s.header = false
+
+	header := model.CSVHeader
+	if s.cdr {
+		header = CDRHeader
+	} else if s.projection != nil {
+		header = s.projection.Header()
+	}
+	if err := s.cw.Write(header); err != nil {
+		return fmt.Errorf("unable to write csv header : %w", err)
+	}
+	return nil
+}
+
+// Flush will push already written events of streaming formats to the writer.
+func (s *WriterSink) Flush() error {
+	if s.cw != nil {
+		s.cw.Flush()
+		if err := s.cw.Error(); err != nil {
+			return err
+		}
+	}
+	return s.w.Flush()
+}
+
+// Close will terminate json array and flush buffered events, underlying writer is not closed.
+func (s *WriterSink) Close() error {
+	if s.cw != nil {
+		if err := s.writeHeader(); err != nil {
+			return err
+		}
+	}
+	if s.cdr {
+		if err := s.cw.Write(CDRTrailer(s.count)); err != nil {
+			return fmt.Errorf("unable to write cdr trailer : %w", err)
+		}
+	}
+	if s.enc == nil && s.cw == nil {
+		if err := s.closeArray(); err != nil {
+			return err
+		}
+	}
+	return s.Flush()
+}
+
+// writeElement will write event as the next element of json array, array is started if it's not started yet.
+func (s *WriterSink) writeElement(e *model.Event) error {
+	var content []byte
+	var err error
+	if s.projection != nil {
+		content, err = s.projection.JSON(e)
+	} else {
+		content, err = json.Marshal(e)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to marshall event : %w", err)
+	}
+
+	if !s.arrayOpen {
+		s.w.WriteByte('[')
+		s.arrayOpen = true
+	}
+	if s.arrayElements {
+		s.w.WriteByte(',')
+	}
+	s.arrayElements = true
+
+	if s.indent != "" {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, content, s.indent, s.indent); err != nil {
+			return fmt.Errorf("unable to indent event : %w", err)
+		}
+		content = indented.Bytes()
+		s.w.WriteString("\n" + s.indent)
+	}
+	_, err = s.w.Write(content)
+	return err
+}
+
+// closeArray will write closing bracket of json array, empty array is started first.
+func (s *WriterSink) closeArray() error {
+	if !s.arrayOpen {
+		s.w.WriteByte('[')
+		s.arrayOpen = true
+	}
+	if s.indent != "" && s.arrayElements {
+		s.w.WriteByte('\n')
+	}
+	return s.w.WriteByte(']')
+}
+
+// FileSink writes events to a file, newline delimited json is the default format.
+type FileSink struct {
+	*WriterSink
+	file *os.File
+	// truncate is set when events overwrite tail of existing json array, so leftovers are cut at Close.
+	truncate bool
+}
+
+// NewFileSink creates sink writing events to provided file in provided format.
+// File is truncated unless append is requested, perm is used for newly created file.
+// Json array can't be appended to as is, use NewArrayAppendSink to continue it.
+func NewFileSink(filename, format string, append bool, perm os.FileMode) (*FileSink, error) {
+	if append && format == FormatJSON {
+		return nil, invalidArgs("append mode is not supported for %s format", FormatJSON)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if append {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(filename, flags, perm)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file : %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unable to stat file : %w", err)
+	}
+
+	w, err := NewWriterSink(file, format, info.Size() == 0)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &FileSink{WriterSink: w, file: file}, nil
+}
+
+// NewArrayAppendSink creates sink continuing json array stored in provided file, perm is used for newly created file.
+// Closing bracket of the array is overwritten once new events are flushed to the file and Close writes it again,
+// so the file is intact if generation fails before any event is flushed.
+// Empty file or file of whitespaces gets a new array.
+func NewArrayAppendSink(filename string, perm os.FileMode) (*FileSink, error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_RDWR, perm)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file : %w", err)
+	}
+
+	offset, arrayOpen, arrayElements, err := findArrayEnd(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unable to seek file : %w", err)
+	}
+
+	w, err := NewWriterSink(file, FormatJSON, false)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	w.arrayOpen, w.arrayElements = arrayOpen, arrayElements
+	return &FileSink{WriterSink: w, file: file, truncate: true}, nil
+}
+
+// findArrayEnd will find closing bracket of json array stored in the file, file is not modified.
+// Returns offset to continue writing at, whether file contains array and whether the array has elements.
+func findArrayEnd(file *os.File) (int64, bool, bool, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, false, false, fmt.Errorf("unable to stat file : %w", err)
+	}
+
+	end, last, err := lastNonSpace(file, info.Size())
+	if err != nil {
+		return 0, false, false, err
+	}
+	if end < 0 {
+		return 0, false, false, nil
+	}
+	if last != ']' {
+		return 0, false, false, badInput("file doesn't end with json array")
+	}
+	first, err := firstNonSpace(file, end)
+	if err != nil {
+		return 0, false, false, err
+	}
+	if first != '[' {
+		return 0, false, false, badInput("file doesn't start with json array")
+	}
+
+	_, prev, err := lastNonSpace(file, end)
+	if err != nil {
+		return 0, false, false, err
+	}
+	return end, true, prev != '[', nil
+}
+
+// firstNonSpace returns value of the first non whitespace byte of the file before end, 0 if there is no such byte.
+func firstNonSpace(file *os.File, end int64) (byte, error) {
+	buf := make([]byte, 4096)
+	for from := int64(0); from < end; from += int64(len(buf)) {
+		chunk := buf
+		if int64(len(chunk)) > end-from {
+			chunk = buf[:end-from]
+		}
+		if _, err := file.ReadAt(chunk, from); err != nil {
+			return 0, fmt.Errorf("unable to read file : %w", err)
+		}
+		for _, c := range chunk {
+			switch c {
+			case ' ', '\t', '\n', '\r':
+			default:
+				return c, nil
+			}
+		}
+	}
+	return 0, nil
+}
+
+// lastNonSpace returns position and value of the last non whitespace byte of the file before end, -1 if there is no such byte.
+func lastNonSpace(file *os.File, end int64) (int64, byte, error) {
+	buf := make([]byte, 4096)
+	for end > 0 {
+		from := end - int64(len(buf))
+		if from < 0 {
+			from = 0
+		}
+
+		chunk := buf[:end-from]
+		if _, err := file.ReadAt(chunk, from); err != nil {
+			return 0, 0, fmt.Errorf("unable to read file : %w", err)
+		}
+		for i := len(chunk) - 1; i >= 0; i-- {
+			switch chunk[i] {
+			case ' ', '\t', '\n', '\r':
+			default:
+				return from + int64(i), chunk[i], nil
+			}
+		}
+		end = from
+	}
+	return -1, 0, nil
+}
+
+// Close will write buffered events and close the file.
+func (s *FileSink) Close() error {
+	defer s.file.Close()
+	if err := s.WriterSink.Close(); err != nil {
+		return err
+	}
+	if !s.truncate {
+		return nil
+	}
+
+	offset, err := s.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("unable to seek file : %w", err)
+	}
+	if err := s.file.Truncate(offset); err != nil {
+		return fmt.Errorf("unable to truncate file : %w", err)
+	}
+	return nil
+}
+
+// countingWriter counts bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// NewStdoutSink creates sink writing events to standard output in provided format.
+func NewStdoutSink(format string) (*WriterSink, error) {
+	return NewWriterSink(os.Stdout, format, true)
+}
+
+// MemorySink keeps events in memory, it's useful for tests.
+type MemorySink struct {
+	// Events are all the written events.
+	Events model.Events
+	// Closed is set once sink is closed.
+	Closed bool
+}
+
+// Write will append event to the sink.
+func (s *MemorySink) Write(e *model.Event) error {
+	s.Events = append(s.Events, e)
+	return nil
+}
+
+// Close will mark the sink closed.
+func (s *MemorySink) Close() error {
+	s.Closed = true
+	return nil
+}
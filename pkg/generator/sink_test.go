@@ -0,0 +1,311 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// writeEvents writes events to the sink and closes it.
+func writeEvents(t *testing.T, s Sink, events model.Events) {
+	t.Helper()
+	for _, e := range events {
+		if err := s.Write(e); err != nil {
+			t.Fatalf("unable to write event : %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("unable to close sink : %v", err)
+	}
+}
+
+// readJSONFile reads json array of events from the file.
+func readJSONFile(t *testing.T, filename string) model.Events {
+	t.Helper()
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read %s : %v", filename, err)
+	}
+	events, err := ReadJSON(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("unable to parse %q : %v", content, err)
+	}
+	return events
+}
+
+func TestArrayAppendSink(t *testing.T) {
+	first := NewEvent(WithRef("ref-1"))
+	second := NewEvent(WithRef("ref-2"))
+	appended := model.Events{NewEvent(WithRef("ref-3")), NewEvent(WithRef("ref-4"))}
+
+	tests := []struct {
+		name     string
+		existing model.Events
+		// suffix is written after existing array.
+		suffix string
+		// raw is file content used instead of existing events.
+		raw string
+	}{
+		{name: "empty file"},
+		{name: "whitespace file", raw: " \n\t\n"},
+		{name: "empty array", raw: "[ ]\n"},
+		{name: "single element", existing: model.Events{first}},
+		{name: "multiple elements", existing: model.Events{first, second}},
+		{name: "trailing whitespace", existing: model.Events{first, second}, suffix: "\n\n  \t\r\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "events.json")
+
+			content := []byte(test.raw)
+			if test.existing != nil {
+				var buf bytes.Buffer
+				if err := WriteJSON(&buf, test.existing); err != nil {
+					t.Fatalf("unable to write existing events : %v", err)
+				}
+				content = append(buf.Bytes(), test.suffix...)
+			}
+			if err := os.WriteFile(filename, content, 0644); err != nil {
+				t.Fatalf("unable to write file : %v", err)
+			}
+
+			s, err := NewArrayAppendSink(filename, 0644)
+			if err != nil {
+				t.Fatalf("unable to create sink : %v", err)
+			}
+			writeEvents(t, s, appended)
+
+			expected := append(append(model.Events{}, test.existing...), appended...)
+			assertEvents(t, expected, readJSONFile(t, filename))
+		})
+	}
+}
+
+func TestArrayAppendSinkKeepsFileUntilClose(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.json")
+	existing := model.Events{NewEvent(WithRef("ref-1"))}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, existing); err != nil {
+		t.Fatalf("unable to write existing events : %v", err)
+	}
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("unable to write file : %v", err)
+	}
+
+	s, err := NewArrayAppendSink(filename, 0644)
+	if err != nil {
+		t.Fatalf("unable to create sink : %v", err)
+	}
+	defer s.file.Close()
+	if err := s.Write(NewEvent(WithRef("ref-2"))); err != nil {
+		t.Fatalf("unable to write event : %v", err)
+	}
+
+	// generation failing before events are flushed must leave a valid array
+	assertEvents(t, existing, readJSONFile(t, filename))
+}
+
+func TestArrayAppendSinkRejectsNonArray(t *testing.T) {
+	for _, content := range []string{
+		"{\"ref\":\"ref-1\"}\n",
+		// object ending with array value ends with closing bracket as well
+		"{\"a\":[1]}",
+		"\n  {\"a\":[1]}\n",
+		"1]",
+	} {
+		filename := filepath.Join(t.TempDir(), "events.json")
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("unable to write file : %v", err)
+		}
+
+		if _, err := NewArrayAppendSink(filename, 0644); !errors.Is(err, ErrBadInput) {
+			t.Errorf("%q : expected bad input error, got %v", content, err)
+		}
+		if actual, err := os.ReadFile(filename); err != nil || string(actual) != content {
+			t.Errorf("%q : expected file to be kept, got %q and error %v", content, actual, err)
+		}
+	}
+}
+
+func TestFileSinkRejectsJSONAppend(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.json")
+	if _, err := NewFileSink(filename, FormatJSON, true, 0644); !errors.Is(err, ErrInvalidArgs) {
+		t.Fatalf("expected invalid arguments error, got %v", err)
+	}
+}
+
+func TestIndentedJSONRoundTrip(t *testing.T) {
+	events := model.Events{
+		NewEvent(WithRef("ref-1"), WithAttributes([8]string{"a", "b"})),
+		NewEvent(WithRef("ref-2")),
+	}
+
+	var buf bytes.Buffer
+	s, err := NewWriterSink(&buf, FormatJSON, false)
+	if err != nil {
+		t.Fatalf("unable to create sink : %v", err)
+	}
+	s.SetIndent("  ")
+	writeEvents(t, s, events)
+
+	content := buf.String()
+	if !strings.HasPrefix(content, "[\n  {\n    \"") || !strings.HasSuffix(content, "\n  }\n]") {
+		t.Errorf("expected event per indented object, got:\n%s", content)
+	}
+
+	parsed, err := ReadJSON(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unable to read indented json : %v", err)
+	}
+	assertEvents(t, events, parsed)
+
+	// loader streams array event by event
+	var streamed model.Events
+	if err := StreamJSON(strings.NewReader(content), func(e *model.Event) error {
+		streamed = append(streamed, e)
+		return nil
+	}); err != nil {
+		t.Fatalf("unable to stream indented json : %v", err)
+	}
+	assertEvents(t, events, streamed)
+}
+
+func TestMemorySink(t *testing.T) {
+	events := model.Events{NewEvent(WithRef("ref-1")), NewEvent(WithRef("ref-2"))}
+
+	s := &MemorySink{}
+	for _, e := range events {
+		if err := s.Write(e); err != nil {
+			t.Fatalf("unable to write event : %v", err)
+		}
+	}
+	if s.Closed {
+		t.Fatal("expected sink to be open before Close")
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("unable to close sink : %v", err)
+	}
+
+	if !s.Closed {
+		t.Error("expected sink to be closed")
+	}
+	if len(s.Events) != len(events) {
+		t.Fatalf("expected %d events, got %d", len(events), len(s.Events))
+	}
+	// events are kept as written, not copied
+	for i, e := range events {
+		if s.Events[i] != e {
+			t.Errorf("event %d : expected %p, got %p", i, e, s.Events[i])
+		}
+	}
+}
+
+// benchmarkSinkEvents is number of events written by every iteration of sink benchmarks.
+const benchmarkSinkEvents = 1000000
+
+func BenchmarkFileSinkBufferSize(b *testing.B) {
+	defer func(size int) { BufferSize = size }(BufferSize)
+
+	// events are cycled, so 1M of them don't have to be kept in memory
+	events := make(model.Events, 1000)
+	for i := range events {
+		events[i] = RandomEvent(Options{})
+	}
+
+	for _, size := range []int{4 << 10, 64 << 10, DefaultBufferSize, 8 << 20} {
+		b.Run(fmt.Sprintf("%dKB", size>>10), func(b *testing.B) {
+			BufferSize = size
+			filename := filepath.Join(b.TempDir(), "events.ndjson")
+
+			for i := 0; i < b.N; i++ {
+				s, err := NewFileSink(filename, FormatNDJSON, false, 0644)
+				if err != nil {
+					b.Fatalf("unable to create sink : %v", err)
+				}
+				for j := 0; j < benchmarkSinkEvents; j++ {
+					if err := s.Write(events[j%len(events)]); err != nil {
+						b.Fatalf("unable to write event : %v", err)
+					}
+				}
+				if err := s.Close(); err != nil {
+					b.Fatalf("unable to close sink : %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFileSinkPermissions(t *testing.T) {
+	for _, perm := range []os.FileMode{0600, 0644} {
+		filename := filepath.Join(t.TempDir(), "events.ndjson")
+		s, err := NewFileSink(filename, FormatNDJSON, false, perm)
+		if err != nil {
+			t.Fatalf("unable to create sink : %v", err)
+		}
+		writeEvents(t, s, model.Events{NewEvent(WithRef("ref-1"))})
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatalf("unable to stat file : %v", err)
+		}
+		// umask may only drop permissions, owner ones are always kept
+		if mode := info.Mode().Perm(); mode&^perm != 0 || mode&0600 != 0600 {
+			t.Errorf("expected mode within %v, got %v", perm, mode)
+		}
+	}
+}
+
+func TestJSONSinkStreamsArray(t *testing.T) {
+	defer func(size int) { BufferSize = size }(BufferSize)
+	BufferSize = 16
+
+	var buf bytes.Buffer
+	s, err := NewWriterSink(&buf, FormatJSON, false)
+	if err != nil {
+		t.Fatalf("unable to create sink : %v", err)
+	}
+	events := model.Events{NewEvent(WithRef("ref-1")), NewEvent(WithRef("ref-2"))}
+	for i, e := range events {
+		if err := s.Write(e); err != nil {
+			t.Fatalf("unable to write event : %v", err)
+		}
+		// events are written as they come rather than kept until Close
+		if size := s.Size(); size == 0 || int64(buf.Len()) > size {
+			t.Errorf("event %d : expected size of written events, got %d with %d bytes written", i, size, buf.Len())
+		}
+	}
+	if !strings.HasPrefix(buf.String(), `[{"event_source"`) || !strings.Contains(buf.String(), `},{"event_source"`) {
+		t.Errorf("expected events streamed before Close, got %s", buf.String())
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("unable to close sink : %v", err)
+	}
+	read, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("unable to read streamed array : %v", err)
+	}
+	assertEvents(t, events, read)
+}
+
+func TestEmptyJSONSink(t *testing.T) {
+	for _, indent := range []string{"", "  "} {
+		var buf bytes.Buffer
+		s, err := NewWriterSink(&buf, FormatJSON, false)
+		if err != nil {
+			t.Fatalf("unable to create sink : %v", err)
+		}
+		s.SetIndent(indent)
+		writeEvents(t, s, nil)
+		if buf.String() != "[]" {
+			t.Errorf("indent %q : expected empty array, got %q", indent, buf.String())
+		}
+	}
+}
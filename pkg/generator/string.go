@@ -1,23 +1,113 @@
 package generator
 
 import (
-	"math/rand"
+	"fmt"
+	"strings"
 	"time"
 )
 
-var letterRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890")
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
 
+// RandomString returns random string with length from 1 to 40 characters.
 func RandomString() string {
-	strLen := rand.Int31n(40)
+	return RandomStringN(int(randInt31n(40)) + 1)
+}
+
+// RandomStringN returns random string of provided length.
+func RandomStringN(n int) string {
+	return RandomCode(n, letters)
+}
+
+// RandomCode returns random code of provided length consisting of alphabet characters, alphabet must be ASCII.
+// Code is built with builder grown to its length, so it's allocated once.
+func RandomCode(length int, alphabet string) string {
+	var code strings.Builder
+	code.Grow(length)
+	for i := 0; i < length; i++ {
+		code.WriteByte(alphabet[randInt31n(int32(len(alphabet)))])
+	}
+	return code.String()
+}
+
+// LocationCode generates event locations, random strings of 1 to 40 letters and digits by default.
+var LocationCode AttributeStrategy = RandomString
 
-	var str string
-	for i := 0; i <= int(strLen); i++ {
-		str = str + string(letterRunes[int(rand.Int31n(int32(len(letterRunes))))])
+// Codes returns strategy generating codes of provided length consisting of alphabet characters,
+// length is random from 1 to 40 if it's 0.
+func Codes(length int, alphabet string) AttributeStrategy {
+	return func() string {
+		if length > 0 {
+			return RandomCode(length, alphabet)
+		}
+		return RandomCode(int(randInt31n(40))+1, alphabet)
 	}
-	return str
 }
 
+// Location is time zone of generated dates, hours of day are weighted in this zone.
+var Location = time.UTC
+
+// Since and Until bound dates generated by RandomDate, default 2010-2020 years range is used if Since is zero.
+var Since, Until time.Time
+
+// RandomDate returns random date between 2010 and 2020 years or between Since and Until if they are set in configured Location.
+// Hour of day is picked according to HourWeights, dates are clustered in spikes for bursty traffic shape.
 func RandomDate() *time.Time {
-	t := time.Date(rand.Intn(11)+2010, time.Month(rand.Intn(12)+1), rand.Intn(28), rand.Intn(23), rand.Intn(59), rand.Intn(59), rand.Intn(59), time.UTC)
+	if bursts.enabled {
+		since, until := Since, Until
+		if since.IsZero() {
+			since, until = time.Date(2010, 1, 1, 0, 0, 0, 0, Location), time.Date(2021, 1, 1, 0, 0, 0, 0, Location)
+		}
+		return burstyDate(since, until, &bursts.starts)
+	}
+	t := randomDate(Since, Until, &HourWeights)
+	return &t
+}
+
+// randomDate returns random date between since and until or between 2010 and 2020 years if since is zero,
+// hour of day is picked according to provided weights.
+func randomDate(since, until time.Time, weights *[24]float64) time.Time {
+	if !since.IsZero() {
+		return dateBetween(since, until, weights)
+	}
+	return time.Date(randIntn(11)+2010, time.Month(randIntn(12)+1), randIntn(28), weightedHour(weights), randIntn(59), randIntn(59), randIntn(59), Location)
+}
+
+// RandomDateBetween returns random date from since inclusively to until exclusively in configured Location.
+// Day is picked uniformly and hour of day according to HourWeights, dates out of the range are drawn again.
+// It panics if since is not before until.
+func RandomDateBetween(since, until time.Time) *time.Time {
+	t := dateBetween(since, until, &HourWeights)
 	return &t
 }
+
+// dateBetween returns random date from since inclusively to until exclusively with hour of day picked according
+// to provided weights.
+func dateBetween(since, until time.Time, weights *[24]float64) time.Time {
+	if !since.Before(until) {
+		panic(fmt.Errorf("invalid date range %v - %v", since, until))
+	}
+
+	since, until = since.In(Location), until.In(Location)
+	first := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, Location)
+	days := int(until.Sub(first).Hours()/24) + 1
+
+	for {
+		day := first.AddDate(0, 0, randIntn(days))
+		t := time.Date(day.Year(), day.Month(), day.Day(), weightedHour(weights), randIntn(60), randIntn(60), randIntn(1000000000), Location)
+		if !t.Before(since) && t.Before(until) {
+			return t
+		}
+	}
+}
+
+// ParseDate parses date in RFC3339 or YYYY-MM-DD form, the latter is midnight in configured Location.
+func ParseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, Location)
+	if err != nil {
+		return time.Time{}, invalidArgs("invalid date '%s', RFC3339 or YYYY-MM-DD expected", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+// concatRandomString builds random string by concatenation like RandomStringN did before,
+// it's the baseline of allocation benchmarks.
+func concatRandomString(n int) string {
+	letterRunes := []rune(letters)
+	var str string
+	for i := 0; i < n; i++ {
+		str = str + string(letterRunes[int(randInt31n(int32(len(letterRunes))))])
+	}
+	return str
+}
+
+func TestRandomStringN(t *testing.T) {
+	seen := map[rune]bool{}
+	for i := 0; i < 1000; i++ {
+		s := RandomStringN(40)
+		if len(s) != 40 {
+			t.Fatalf("expected 40 characters, got %q", s)
+		}
+		for _, c := range s {
+			if !strings.ContainsRune(letters, c) {
+				t.Fatalf("expected characters of %s, got %q", letters, s)
+			}
+			seen[c] = true
+		}
+	}
+	// 40000 characters drawn uniformly cover the whole alphabet
+	if len(seen) != len(letters) {
+		t.Errorf("expected all %d characters of alphabet, got %d", len(letters), len(seen))
+	}
+}
+
+// assertCodes fails the test if codes don't have provided length or contain characters out of the alphabet.
+func assertCodes(t *testing.T, codes []string, length int, alphabet string) {
+	t.Helper()
+	for _, code := range codes {
+		if len(code) != length {
+			t.Fatalf("expected code of %d characters, got %q", length, code)
+		}
+		if strings.Trim(code, alphabet) != "" {
+			t.Fatalf("expected characters of %s, got %q", alphabet, code)
+		}
+	}
+}
+
+func TestRandomCode(t *testing.T) {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	codes := make([]string, 0, 1000)
+	for i := 0; i < cap(codes); i++ {
+		codes = append(codes, RandomCode(5, alphabet))
+	}
+	assertCodes(t, codes, 5, alphabet)
+
+	if code := RandomCode(0, alphabet); code != "" {
+		t.Errorf("expected empty code, got %q", code)
+	}
+}
+
+func TestLocationCodes(t *testing.T) {
+	defer func(strategy AttributeStrategy) { LocationCode = strategy }(LocationCode)
+	const alphabet = "ABC123"
+	LocationCode = Codes(5, alphabet)
+
+	locations := make([]string, 0, 1000)
+	for i := 0; i < cap(locations); i++ {
+		locations = append(locations, RandomEvent(Options{}).Location)
+	}
+	assertCodes(t, locations, 5, alphabet)
+}
+
+func TestCodesOfRandomLength(t *testing.T) {
+	const alphabet = "XY"
+	codes := Codes(0, alphabet)
+
+	lengths := map[int]bool{}
+	for i := 0; i < 10000; i++ {
+		code := codes()
+		if len(code) < 1 || len(code) > 40 || strings.Trim(code, alphabet) != "" {
+			t.Fatalf("expected 1 to 40 characters of %s, got %q", alphabet, code)
+		}
+		lengths[len(code)] = true
+	}
+	if len(lengths) != 40 {
+		t.Errorf("expected every length from 1 to 40, got %d lengths", len(lengths))
+	}
+}
+
+func TestDefaultLocations(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		location := RandomEvent(Options{}).Location
+		if len(location) < 1 || len(location) > 40 || strings.Trim(location, letters) != "" {
+			t.Fatalf("expected 1 to 40 letters and digits, got %q", location)
+		}
+	}
+}
+
+func BenchmarkRandomString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		RandomStringN(40)
+	}
+}
+
+func BenchmarkRandomStringConcat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		concatRandomString(40)
+	}
+}
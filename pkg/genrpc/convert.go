@@ -0,0 +1,50 @@
+package genrpc
+
+import (
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FromModel converts event to its protobuf message.
+func FromModel(e *model.Event) *Event {
+	return &Event{
+		EventSource:     int64(e.EventSource),
+		EventRef:        e.EventRef,
+		EventType:       int32(e.EventType),
+		EventDate:       timestamppb.New(e.EventDate),
+		CallingNumber:   int64(e.CallingNumber),
+		CalledNumber:    int64(e.CalledNumber),
+		Location:        e.Location,
+		DurationSeconds: int64(e.DurationSeconds),
+		Attr_1:          e.Attr1,
+		Attr_2:          e.Attr2,
+		Attr_3:          e.Attr3,
+		Attr_4:          e.Attr4,
+		Attr_5:          e.Attr5,
+		Attr_6:          e.Attr6,
+		Attr_7:          e.Attr7,
+		Attr_8:          e.Attr8,
+	}
+}
+
+// ToModel converts protobuf message to event, event date is in UTC as protobuf timestamps don't keep zone.
+func ToModel(e *Event) *model.Event {
+	return &model.Event{
+		EventSource:     int(e.EventSource),
+		EventRef:        e.EventRef,
+		EventType:       model.EventType(e.EventType),
+		EventDate:       e.EventDate.AsTime(),
+		CallingNumber:   int(e.CallingNumber),
+		CalledNumber:    int(e.CalledNumber),
+		Location:        e.Location,
+		DurationSeconds: int(e.DurationSeconds),
+		Attr1:           e.Attr_1,
+		Attr2:           e.Attr_2,
+		Attr3:           e.Attr_3,
+		Attr4:           e.Attr_4,
+		Attr5:           e.Attr_5,
+		Attr6:           e.Attr_6,
+		Attr7:           e.Attr_7,
+		Attr8:           e.Attr_8,
+	}
+}
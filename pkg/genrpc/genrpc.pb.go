@@ -0,0 +1,392 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.12
+// source: genrpc.proto
+
+package genrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GenerateRequest configures generated events.
+type GenerateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// count is number of events to generate.
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// seed of random generator, a new seed is used if it's 0.
+	Seed int64 `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	// distribution of event types like 1:15,2:20,3:20,5:45, default distribution is used if it's empty.
+	Distribution string `protobuf:"bytes,3,opt,name=distribution,proto3" json:"distribution,omitempty"`
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genrpc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_genrpc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_genrpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GenerateRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *GenerateRequest) GetDistribution() string {
+	if x != nil {
+		return x.Distribution
+	}
+	return ""
+}
+
+// Event mirrors model.Event, field numbers follow the order of its fields.
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventSource     int64                  `protobuf:"varint,1,opt,name=event_source,json=eventSource,proto3" json:"event_source,omitempty"`
+	EventRef        string                 `protobuf:"bytes,2,opt,name=event_ref,json=eventRef,proto3" json:"event_ref,omitempty"`
+	EventType       int32                  `protobuf:"varint,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	EventDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=event_date,json=eventDate,proto3" json:"event_date,omitempty"`
+	CallingNumber   int64                  `protobuf:"varint,5,opt,name=calling_number,json=callingNumber,proto3" json:"calling_number,omitempty"`
+	CalledNumber    int64                  `protobuf:"varint,6,opt,name=called_number,json=calledNumber,proto3" json:"called_number,omitempty"`
+	Location        string                 `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,8,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Attr_1          string                 `protobuf:"bytes,9,opt,name=attr_1,json=attr1,proto3" json:"attr_1,omitempty"`
+	Attr_2          string                 `protobuf:"bytes,10,opt,name=attr_2,json=attr2,proto3" json:"attr_2,omitempty"`
+	Attr_3          string                 `protobuf:"bytes,11,opt,name=attr_3,json=attr3,proto3" json:"attr_3,omitempty"`
+	Attr_4          string                 `protobuf:"bytes,12,opt,name=attr_4,json=attr4,proto3" json:"attr_4,omitempty"`
+	Attr_5          string                 `protobuf:"bytes,13,opt,name=attr_5,json=attr5,proto3" json:"attr_5,omitempty"`
+	Attr_6          string                 `protobuf:"bytes,14,opt,name=attr_6,json=attr6,proto3" json:"attr_6,omitempty"`
+	Attr_7          string                 `protobuf:"bytes,15,opt,name=attr_7,json=attr7,proto3" json:"attr_7,omitempty"`
+	Attr_8          string                 `protobuf:"bytes,16,opt,name=attr_8,json=attr8,proto3" json:"attr_8,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genrpc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_genrpc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_genrpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Event) GetEventSource() int64 {
+	if x != nil {
+		return x.EventSource
+	}
+	return 0
+}
+
+func (x *Event) GetEventRef() string {
+	if x != nil {
+		return x.EventRef
+	}
+	return ""
+}
+
+func (x *Event) GetEventType() int32 {
+	if x != nil {
+		return x.EventType
+	}
+	return 0
+}
+
+func (x *Event) GetEventDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventDate
+	}
+	return nil
+}
+
+func (x *Event) GetCallingNumber() int64 {
+	if x != nil {
+		return x.CallingNumber
+	}
+	return 0
+}
+
+func (x *Event) GetCalledNumber() int64 {
+	if x != nil {
+		return x.CalledNumber
+	}
+	return 0
+}
+
+func (x *Event) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Event) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *Event) GetAttr_1() string {
+	if x != nil {
+		return x.Attr_1
+	}
+	return ""
+}
+
+func (x *Event) GetAttr_2() string {
+	if x != nil {
+		return x.Attr_2
+	}
+	return ""
+}
+
+func (x *Event) GetAttr_3() string {
+	if x != nil {
+		return x.Attr_3
+	}
+	return ""
+}
+
+func (x *Event) GetAttr_4() string {
+	if x != nil {
+		return x.Attr_4
+	}
+	return ""
+}
+
+func (x *Event) GetAttr_5() string {
+	if x != nil {
+		return x.Attr_5
+	}
+	return ""
+}
+
+func (x *Event) GetAttr_6() string {
+	if x != nil {
+		return x.Attr_6
+	}
+	return ""
+}
+
+func (x *Event) GetAttr_7() string {
+	if x != nil {
+		return x.Attr_7
+	}
+	return ""
+}
+
+func (x *Event) GetAttr_8() string {
+	if x != nil {
+		return x.Attr_8
+	}
+	return ""
+}
+
+var File_genrpc_proto protoreflect.FileDescriptor
+
+var file_genrpc_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x67, 0x65, 0x6e, 0x72, 0x70, 0x63, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5f, 0x0a, 0x0f, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x65, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xec, 0x03, 0x0a, 0x05, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72,
+	0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x61, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x61, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x15, 0x0a, 0x06, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x31, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x61, 0x74, 0x74, 0x72, 0x31, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x32,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x74, 0x74, 0x72, 0x32, 0x12, 0x15, 0x0a,
+	0x06, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x33, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61,
+	0x74, 0x74, 0x72, 0x33, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x34, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x74, 0x74, 0x72, 0x34, 0x12, 0x15, 0x0a, 0x06, 0x61,
+	0x74, 0x74, 0x72, 0x5f, 0x35, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x74, 0x74,
+	0x72, 0x35, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x36, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x61, 0x74, 0x74, 0x72, 0x36, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x74, 0x74,
+	0x72, 0x5f, 0x37, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x74, 0x74, 0x72, 0x37,
+	0x12, 0x15, 0x0a, 0x06, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x38, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x74, 0x74, 0x72, 0x38, 0x32, 0x47, 0x0a, 0x09, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6e, 0x72, 0x70, 0x63, 0x2e,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0d, 0x2e, 0x67, 0x65, 0x6e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01,
+	0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64,
+	0x6d, 0x67, 0x6f, 0x31, 0x30, 0x31, 0x34, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x69, 0x65,
+	0x77, 0x69, 0x6e, 0x67, 0x2d, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2e, 0x67, 0x69, 0x74, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_genrpc_proto_rawDescOnce sync.Once
+	file_genrpc_proto_rawDescData = file_genrpc_proto_rawDesc
+)
+
+func file_genrpc_proto_rawDescGZIP() []byte {
+	file_genrpc_proto_rawDescOnce.Do(func() {
+		file_genrpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_genrpc_proto_rawDescData)
+	})
+	return file_genrpc_proto_rawDescData
+}
+
+var file_genrpc_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_genrpc_proto_goTypes = []interface{}{
+	(*GenerateRequest)(nil),       // 0: genrpc.GenerateRequest
+	(*Event)(nil),                 // 1: genrpc.Event
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_genrpc_proto_depIdxs = []int32{
+	2, // 0: genrpc.Event.event_date:type_name -> google.protobuf.Timestamp
+	0, // 1: genrpc.Generator.GenerateEvents:input_type -> genrpc.GenerateRequest
+	1, // 2: genrpc.Generator.GenerateEvents:output_type -> genrpc.Event
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_genrpc_proto_init() }
+func file_genrpc_proto_init() {
+	if File_genrpc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_genrpc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genrpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_genrpc_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_genrpc_proto_goTypes,
+		DependencyIndexes: file_genrpc_proto_depIdxs,
+		MessageInfos:      file_genrpc_proto_msgTypes,
+	}.Build()
+	File_genrpc_proto = out.File
+	file_genrpc_proto_rawDesc = nil
+	file_genrpc_proto_goTypes = nil
+	file_genrpc_proto_depIdxs = nil
+}
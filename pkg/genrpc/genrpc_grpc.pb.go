@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: genrpc.proto
+
+package genrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Generator_GenerateEvents_FullMethodName = "/genrpc.Generator/GenerateEvents"
+)
+
+// GeneratorClient is the client API for Generator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GeneratorClient interface {
+	// GenerateEvents streams requested number of generated events.
+	GenerateEvents(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (Generator_GenerateEventsClient, error)
+}
+
+type generatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeneratorClient(cc grpc.ClientConnInterface) GeneratorClient {
+	return &generatorClient{cc}
+}
+
+func (c *generatorClient) GenerateEvents(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (Generator_GenerateEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Generator_ServiceDesc.Streams[0], Generator_GenerateEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &generatorGenerateEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Generator_GenerateEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type generatorGenerateEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *generatorGenerateEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GeneratorServer is the server API for Generator service.
+// All implementations must embed UnimplementedGeneratorServer
+// for forward compatibility
+type GeneratorServer interface {
+	// GenerateEvents streams requested number of generated events.
+	GenerateEvents(*GenerateRequest, Generator_GenerateEventsServer) error
+	mustEmbedUnimplementedGeneratorServer()
+}
+
+// UnimplementedGeneratorServer must be embedded to have forward compatible implementations.
+type UnimplementedGeneratorServer struct {
+}
+
+func (UnimplementedGeneratorServer) GenerateEvents(*GenerateRequest, Generator_GenerateEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateEvents not implemented")
+}
+func (UnimplementedGeneratorServer) mustEmbedUnimplementedGeneratorServer() {}
+
+// UnsafeGeneratorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeneratorServer will
+// result in compilation errors.
+type UnsafeGeneratorServer interface {
+	mustEmbedUnimplementedGeneratorServer()
+}
+
+func RegisterGeneratorServer(s grpc.ServiceRegistrar, srv GeneratorServer) {
+	s.RegisterService(&Generator_ServiceDesc, srv)
+}
+
+func _Generator_GenerateEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeneratorServer).GenerateEvents(m, &generatorGenerateEventsServer{stream})
+}
+
+type Generator_GenerateEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type generatorGenerateEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *generatorGenerateEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Generator_ServiceDesc is the grpc.ServiceDesc for Generator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Generator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "genrpc.Generator",
+	HandlerType: (*GeneratorServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateEvents",
+			Handler:       _Generator_GenerateEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "genrpc.proto",
+}
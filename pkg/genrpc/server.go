@@ -0,0 +1,70 @@
+package genrpc
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/generator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server streams generated events.
+//
+// Generator package draws values from a single random source, so requests are served one at a time
+// to keep seeded streams reproducible.
+type Server struct {
+	UnimplementedGeneratorServer
+
+	mu sync.Mutex
+}
+
+// NewServer creates generation server.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// GenerateEvents streams requested number of events generated with requested seed and distribution.
+func (s *Server) GenerateEvents(req *GenerateRequest, stream Generator_GenerateEventsServer) error {
+	if req.Count < 0 {
+		return status.Errorf(codes.InvalidArgument, "number of events must not be negative, got %d", req.Count)
+	}
+
+	dist := generator.DefaultDistribution()
+	if req.Distribution != "" {
+		var err error
+		dist, err = generator.ParseDistribution(req.Distribution)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid distribution : %v", err)
+		}
+	}
+
+	refs, err := generator.NewRefGenerator(4, false)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to create ref generator : %v", err)
+	}
+
+	seed := req.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	generator.SetSource(rand.NewSource(seed))
+	defer generator.SetSource(nil)
+
+	for i := int64(0); i < req.Count; i++ {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		e := generator.NewEvent(generator.WithRandomDefaults(refs, dist))
+		if err := stream.Send(FromModel(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics exposes progress of a long running job on /metrics endpoint in Prometheus format,
+// /healthz and /readyz endpoints report liveness and readiness of the job, e.g. for Kubernetes probes.
+// All the methods are safe to call on nil Metrics, so callers don't have to check whether metrics are enabled.
+type Metrics struct {
+	events        prometheus.Counter
+	throughput    prometheus.Gauge
+	batchDuration prometheus.Histogram
+
+	// ready is set once job is initialized and starts processing events.
+	ready  atomic.Bool
+	server *http.Server
+}
+
+// New creates metrics of provided job, job is used as metrics prefix, e.g. generator_events_total.
+func New(job string) *Metrics {
+	m := &Metrics{
+		events: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: job + "_events_total",
+			Help: "Total number of processed events.",
+		}),
+		throughput: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: job + "_throughput_events_per_second",
+			Help: "Number of events per second processed by the last batch.",
+		}),
+		batchDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    job + "_batch_duration_seconds",
+			Help:    "Duration of processing of a single batch of events.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+		}),
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m.events, m.throughput, m.batchDuration)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !m.ready.Load() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	m.server = &http.Server{Handler: mux}
+
+	return m
+}
+
+// Serve will start serving metrics on provided address in background.
+func (m *Metrics) Serve(addr string) error {
+	if m == nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s : %w", addr, err)
+	}
+
+	go func() {
+		if err := m.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("metrics server failed : %+v\n", err)
+		}
+	}()
+	return nil
+}
+
+// SetReady will set readiness reported by /readyz endpoint.
+func (m *Metrics) SetReady(ready bool) {
+	if m == nil {
+		return
+	}
+	m.ready.Store(ready)
+}
+
+// Shutdown will stop metrics server waiting for active scrapes to finish.
+func (m *Metrics) Shutdown() error {
+	if m == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return m.server.Shutdown(ctx)
+}
+
+// ObserveBatch will record batch of provided number of events processed within duration.
+func (m *Metrics) ObserveBatch(events int, duration time.Duration) {
+	if m == nil || events == 0 {
+		return
+	}
+
+	m.events.Add(float64(events))
+	m.batchDuration.Observe(duration.Seconds())
+	if duration > 0 {
+		m.throughput.Set(float64(events) / duration.Seconds())
+	}
+}
+
+// Batcher splits stream of events into batches of fixed size and reports them to metrics.
+type Batcher struct {
+	metrics *Metrics
+	size    int
+	count   int
+	start   time.Time
+}
+
+// NewBatcher creates batcher reporting every size events to provided metrics.
+func NewBatcher(m *Metrics, size int) *Batcher {
+	return &Batcher{metrics: m, size: size, start: time.Now()}
+}
+
+// Inc will account a single processed event.
+func (b *Batcher) Inc() {
+	if b.metrics == nil {
+		return
+	}
+
+	b.count++
+	if b.count >= b.size {
+		b.Flush()
+	}
+}
+
+// Flush will report events accounted since the last report.
+func (b *Batcher) Flush() {
+	if b.metrics == nil {
+		return
+	}
+
+	b.metrics.ObserveBatch(b.count, time.Since(b.start))
+	b.count = 0
+	b.start = time.Now()
+}
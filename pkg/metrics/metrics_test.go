@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// get returns status code and body of response of the metrics handler.
+func get(t *testing.T, m *Metrics, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestProbes(t *testing.T) {
+	m := New("test")
+
+	tests := []struct {
+		name            string
+		ready           bool
+		healthz, readyz int
+	}{
+		{name: "before ready", healthz: http.StatusOK, readyz: http.StatusServiceUnavailable},
+		{name: "ready", ready: true, healthz: http.StatusOK, readyz: http.StatusOK},
+		{name: "no longer ready", healthz: http.StatusOK, readyz: http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		m.SetReady(test.ready)
+		if code, _ := get(t, m, "/healthz"); code != test.healthz {
+			t.Errorf("%s : expected /healthz status %d, got %d", test.name, test.healthz, code)
+		}
+		if code, _ := get(t, m, "/readyz"); code != test.readyz {
+			t.Errorf("%s : expected /readyz status %d, got %d", test.name, test.readyz, code)
+		}
+	}
+}
+
+func TestMetricsExposeBatches(t *testing.T) {
+	m := New("test")
+	m.ObserveBatch(1000, 100*time.Millisecond)
+
+	code, body := get(t, m, "/metrics")
+	if code != http.StatusOK {
+		t.Fatalf("expected /metrics status 200, got %d", code)
+	}
+	for _, metric := range []string{"test_events_total 1000", "test_throughput_events_per_second 10000", "test_batch_duration_seconds_count 1"} {
+		if !strings.Contains(body, metric) {
+			t.Errorf("expected %s in metrics, got:\n%s", metric, body)
+		}
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	// disabled metrics are nil, so jobs call them unconditionally
+	var m *Metrics
+	m.SetReady(true)
+	m.ObserveBatch(1, time.Second)
+	if err := m.Serve(":0"); err != nil {
+		t.Errorf("expected nil metrics not to serve, got %v", err)
+	}
+	if err := m.Shutdown(); err != nil {
+		t.Errorf("expected nil metrics shutdown to succeed, got %v", err)
+	}
+}
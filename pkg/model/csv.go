@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CSVHeader is the header of CSV representation of events, columns are named as json fields.
+// Optional duration_millis is not a column, so CSV layout is kept.
+var CSVHeader = []string{
+	"event_source", "event_ref", "event_type", "event_date", "calling_number", "called_number", "location",
+	"duration_seconds", "attr_1", "attr_2", "attr_3", "attr_4", "attr_5", "attr_6", "attr_7", "attr_8",
+}
+
+// CSVRecord returns event as CSV record with columns ordered as in CSVHeader.
+func (e *Event) CSVRecord() []string {
+	return []string{
+		strconv.Itoa(e.EventSource),
+		e.EventRef,
+		strconv.Itoa(int(e.EventType)),
+		e.EventDate.Format(time.RFC3339Nano),
+		strconv.Itoa(e.CallingNumber),
+		strconv.Itoa(e.CalledNumber),
+		e.Location,
+		strconv.Itoa(e.DurationSeconds),
+		e.Attr1,
+		e.Attr2,
+		e.Attr3,
+		e.Attr4,
+		e.Attr5,
+		e.Attr6,
+		e.Attr7,
+		e.Attr8,
+	}
+}
+
+// EventFromCSVRecord parses event from CSV record with columns ordered as in CSVHeader.
+func EventFromCSVRecord(record []string) (*Event, error) {
+	if len(record) != len(CSVHeader) {
+		return nil, fmt.Errorf("invalid number of columns, %d expected, got %d", len(CSVHeader), len(record))
+	}
+
+	var e Event
+	var err error
+
+	ints := []struct {
+		column int
+		target *int
+	}{
+		{0, &e.EventSource},
+		{4, &e.CallingNumber},
+		{5, &e.CalledNumber},
+		{7, &e.DurationSeconds},
+	}
+	for _, i := range ints {
+		*i.target, err = strconv.Atoi(record[i.column])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s : %w", CSVHeader[i.column], err)
+		}
+	}
+
+	eventType, err := strconv.Atoi(record[2])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s : %w", CSVHeader[2], err)
+	}
+	e.EventType = EventType(eventType)
+
+	e.EventDate, err = time.Parse(time.RFC3339Nano, record[3])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s : %w", CSVHeader[3], err)
+	}
+
+	e.EventRef = record[1]
+	e.Location = record[6]
+	e.Attr1 = record[8]
+	e.Attr2 = record[9]
+	e.Attr3 = record[10]
+	e.Attr4 = record[11]
+	e.Attr5 = record[12]
+	e.Attr6 = record[13]
+	e.Attr7 = record[14]
+	e.Attr8 = record[15]
+
+	return &e, nil
+}
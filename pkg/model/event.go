@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
 
 // Event is a single billable occurrence of product usage.
 type Event struct {
@@ -9,7 +14,7 @@ type Event struct {
 	// EventRef is unique event identifier across all the events.
 	EventRef string `json:"event_ref"`
 	// EventType is type of service to which the event relates. Roaming/non-roaming.
-	EventType int `json:"event_type"`
+	EventType EventType `json:"event_type"`
 	// EventDate datetime of event.
 	EventDate time.Time `json:"event_date"`
 	// CallingNumber the person initiated event.
@@ -20,6 +25,9 @@ type Event struct {
 	Location string `json:"location"`
 	// DurationSeconds is duration of event in seconds.
 	DurationSeconds int `json:"duration_seconds"`
+	// DurationMillis is duration of event in milliseconds for services billed in fractions of second,
+	// DurationSeconds is derived from it. It's zero if duration is measured in seconds only.
+	DurationMillis int `json:"duration_millis,omitempty"`
 	// Attr1 is configurable attribute number 1.
 	Attr1 string `json:"attr_1"`
 	// Attr2 is configurable attribute number 1.
@@ -37,3 +45,42 @@ type Event struct {
 	// Attr8 is configurable attribute number 1.
 	Attr8 string `json:"attr_8"`
 }
+
+// Attributes returns values of Attr1-Attr8 in order.
+func (e *Event) Attributes() [8]string {
+	return [8]string{e.Attr1, e.Attr2, e.Attr3, e.Attr4, e.Attr5, e.Attr6, e.Attr7, e.Attr8}
+}
+
+// SetAttributes sets Attr1-Attr8 from provided values in order.
+func (e *Event) SetAttributes(attrs [8]string) {
+	e.Attr1, e.Attr2, e.Attr3, e.Attr4 = attrs[0], attrs[1], attrs[2], attrs[3]
+	e.Attr5, e.Attr6, e.Attr7, e.Attr8 = attrs[4], attrs[5], attrs[6], attrs[7]
+}
+
+// Clone returns a copy of the event, changes of the copy don't affect the original.
+// All the fields are values, so the copy is deep.
+func (e *Event) Clone() *Event {
+	if e == nil {
+		return nil
+	}
+	clone := *e
+	return &clone
+}
+
+// Hash returns hex encoded SHA-256 of all the fields except EventRef, so events with the same content
+// generated by different runs have the same hash. Event date is hashed in UTC, so the same instant
+// in different zones has the same hash.
+func (e *Event) Hash() string {
+	h := sha256.New()
+	// strings are length prefixed, so values can't be shifted between fields
+	fmt.Fprintf(h, "%d|%d|%s|%d|%d|%d:%s|%d", e.EventSource, e.EventType, e.EventDate.UTC().Format(time.RFC3339Nano),
+		e.CallingNumber, e.CalledNumber, len(e.Location), e.Location, e.DurationSeconds)
+	for _, attr := range e.Attributes() {
+		fmt.Fprintf(h, "|%d:%s", len(attr), attr)
+	}
+	// hashes of events without millis are the same as before millis were added
+	if e.DurationMillis != 0 {
+		fmt.Fprintf(h, "|%d", e.DurationMillis)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHashIgnoresRef(t *testing.T) {
+	e, other := testEvent(), testEvent()
+	other.EventRef = "f6e5d4c3-b2a1-4c8e-9a57-1f2b3c4d5e6f"
+
+	if e.Hash() != other.Hash() {
+		t.Error("expected events differing in ref only to have the same hash")
+	}
+}
+
+func TestHashDependsOnFields(t *testing.T) {
+	changes := map[string]func(e *Event){
+		"event_source":     func(e *Event) { e.EventSource++ },
+		"event_type":       func(e *Event) { e.EventType = EventTypeRoaming },
+		"event_date":       func(e *Event) { e.EventDate = e.EventDate.Add(time.Nanosecond) },
+		"calling_number":   func(e *Event) { e.CallingNumber++ },
+		"called_number":    func(e *Event) { e.CalledNumber++ },
+		"location":         func(e *Event) { e.Location = "ABD" },
+		"duration_seconds": func(e *Event) { e.DurationSeconds++ },
+		"duration_millis":  func(e *Event) { e.DurationMillis++ },
+		"attr_1":           func(e *Event) { e.Attr1 = "b1" },
+		"attr_2":           func(e *Event) { e.Attr2 = "b2" },
+		"attr_3":           func(e *Event) { e.Attr3 = "b3" },
+		"attr_4":           func(e *Event) { e.Attr4 = "b4" },
+		"attr_5":           func(e *Event) { e.Attr5 = "b5" },
+		"attr_6":           func(e *Event) { e.Attr6 = "b6" },
+		"attr_7":           func(e *Event) { e.Attr7 = "b7" },
+		"attr_8":           func(e *Event) { e.Attr8 = "b8" },
+	}
+
+	hash := testEvent().Hash()
+	for name, change := range changes {
+		e := testEvent()
+		change(e)
+		if e.Hash() == hash {
+			t.Errorf("expected change of %s to change the hash", name)
+		}
+	}
+}
+
+func TestHashIgnoresDateLocation(t *testing.T) {
+	e, other := testEvent(), testEvent()
+	other.EventDate = other.EventDate.In(time.FixedZone("UTC+3", 3*60*60))
+
+	if e.Hash() != other.Hash() {
+		t.Error("expected the same instant in different locations to have the same hash")
+	}
+}
+
+func TestCloneIsolatesMutations(t *testing.T) {
+	original := testEvent()
+	expected := *original
+
+	clone := original.Clone()
+	if clone == original {
+		t.Fatal("expected clone to be a new event")
+	}
+	if *clone != *original {
+		t.Fatalf("expected clone %+v, got %+v", *original, *clone)
+	}
+
+	clone.EventRef = "clone"
+	clone.EventType = EventTypeRoaming
+	clone.EventDate = clone.EventDate.AddDate(1, 0, 0)
+	clone.DurationMillis = 0
+	clone.SetAttributes([8]string{"changed"})
+	if *original != expected {
+		t.Errorf("expected original to stay %+v, got %+v", expected, *original)
+	}
+
+	// original mutations don't leak into the clone either
+	clone = original.Clone()
+	original.Location = "changed"
+	if clone.Location != expected.Location {
+		t.Errorf("expected clone location %s, got %s", expected.Location, clone.Location)
+	}
+}
+
+func TestCloneOfNil(t *testing.T) {
+	var e *Event
+	if clone := e.Clone(); clone != nil {
+		t.Errorf("expected nil clone, got %+v", clone)
+	}
+}
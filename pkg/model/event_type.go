@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EventType is type of service to which the event relates.
+// It's marshaled to JSON as a plain integer.
+type EventType int
+
+const (
+	// EventTypeStandardCall is a regular voice call.
+	EventTypeStandardCall EventType = 1
+	// EventTypeSMS is a short message.
+	EventTypeSMS EventType = 2
+	// EventTypePremiumService is usage of premium rated service.
+	EventTypePremiumService EventType = 3
+	// EventTypeRoaming is usage of services in roaming.
+	EventTypeRoaming EventType = 4
+	// EventTypeDataSession is mobile data session.
+	EventTypeDataSession EventType = 5
+)
+
+// eventTypeNames are human readable labels of known event types.
+var eventTypeNames = map[EventType]string{
+	EventTypeStandardCall:   "standard_call",
+	EventTypeSMS:            "sms",
+	EventTypePremiumService: "premium_service",
+	EventTypeRoaming:        "roaming",
+	EventTypeDataSession:    "data_session",
+}
+
+// ValidEventTypes are event types accepted by validation and generation.
+// It could be overridden to support types which are not known yet.
+var ValidEventTypes = []EventType{
+	EventTypeStandardCall,
+	EventTypeSMS,
+	EventTypePremiumService,
+	EventTypeRoaming,
+	EventTypeDataSession,
+}
+
+// IsValid reports whether event type is one of ValidEventTypes.
+func (t EventType) IsValid() bool {
+	for _, valid := range ValidEventTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns human readable label of event type.
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("EventType(%d)", int(t))
+}
+
+// ParseEventType parses event type from its label or numeric value, the type must be one of ValidEventTypes.
+func ParseEventType(s string) (EventType, error) {
+	s = strings.TrimSpace(s)
+
+	t, ok := EventType(0), false
+	for known, name := range eventTypeNames {
+		if strings.EqualFold(name, s) {
+			t, ok = known, true
+			break
+		}
+	}
+	if !ok {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("unknown event type '%s'", s)
+		}
+		t = EventType(n)
+	}
+
+	if !t.IsValid() {
+		return 0, fmt.Errorf("event type '%s' is not valid, valid types are %v", s, ValidEventTypes)
+	}
+	return t, nil
+}
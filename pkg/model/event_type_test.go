@@ -0,0 +1,125 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEventTypeMarshalsAsInteger(t *testing.T) {
+	content, err := json.Marshal(struct {
+		EventType EventType `json:"event_type"`
+	}{EventTypeDataSession})
+	if err != nil {
+		t.Fatalf("unable to marshall event type : %v", err)
+	}
+	if string(content) != `{"event_type":5}` {
+		t.Errorf("expected event type as integer, got %s", content)
+	}
+
+	var decoded struct {
+		EventType EventType `json:"event_type"`
+	}
+	if err := json.Unmarshal([]byte(`{"event_type":3}`), &decoded); err != nil {
+		t.Fatalf("unable to unmarshall event type : %v", err)
+	}
+	if decoded.EventType != EventTypePremiumService {
+		t.Errorf("expected %v, got %v", EventTypePremiumService, decoded.EventType)
+	}
+}
+
+func TestParseEventTypeRoundTrip(t *testing.T) {
+	for _, eventType := range ValidEventTypes {
+		parsed, err := ParseEventType(eventType.String())
+		if err != nil {
+			t.Errorf("unable to parse label of %d : %v", int(eventType), err)
+		} else if parsed != eventType {
+			t.Errorf("label %s : expected %d, got %d", eventType, int(eventType), int(parsed))
+		}
+
+		content, err := json.Marshal(eventType)
+		if err != nil {
+			t.Fatalf("unable to marshall event type : %v", err)
+		}
+		parsed, err = ParseEventType(string(content))
+		if err != nil {
+			t.Errorf("unable to parse number %s : %v", content, err)
+		} else if parsed != eventType {
+			t.Errorf("number %s : expected %d, got %d", content, int(eventType), int(parsed))
+		}
+	}
+}
+
+func TestParseEventType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected EventType
+		valid    bool
+	}{
+		{input: "sms", expected: EventTypeSMS, valid: true},
+		{input: " Roaming ", expected: EventTypeRoaming, valid: true},
+		{input: "1", expected: EventTypeStandardCall, valid: true},
+		{input: "0"},
+		{input: "6"},
+		{input: "-1"},
+		{input: "video_call"},
+		{input: ""},
+	}
+
+	for _, test := range tests {
+		parsed, err := ParseEventType(test.input)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("expected '%s' to be rejected, got %d", test.input, int(parsed))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unable to parse '%s' : %v", test.input, err)
+		} else if parsed != test.expected {
+			t.Errorf("'%s' : expected %d, got %d", test.input, int(test.expected), int(parsed))
+		}
+	}
+}
+
+func TestParseEventTypeAcceptsConfiguredTypes(t *testing.T) {
+	defer func(types []EventType) { ValidEventTypes = types }(ValidEventTypes)
+	ValidEventTypes = append(append([]EventType{}, ValidEventTypes...), 6)
+
+	parsed, err := ParseEventType("6")
+	if err != nil {
+		t.Fatalf("unable to parse configured event type : %v", err)
+	}
+	if parsed != 6 {
+		t.Errorf("expected 6, got %d", int(parsed))
+	}
+	if parsed.String() != "EventType(6)" {
+		t.Errorf("expected EventType(6) label of unknown type, got %s", parsed)
+	}
+}
+
+func TestValidateUsesConfiguredTypes(t *testing.T) {
+	defer func(types []EventType) { ValidEventTypes = types }(ValidEventTypes)
+
+	e := testEvent()
+	e.EventType = EventTypeRoaming
+	if err := e.Validate(); err != nil {
+		t.Errorf("expected roaming to be valid by default : %v", err)
+	}
+
+	ValidEventTypes = []EventType{EventTypeStandardCall, EventTypeSMS, 7}
+	for _, eventType := range []EventType{EventTypeStandardCall, EventTypeSMS, 7} {
+		e.EventType = eventType
+		if err := e.Validate(); err != nil {
+			t.Errorf("expected configured type %d to be valid : %v", int(eventType), err)
+		}
+	}
+	for _, eventType := range []EventType{EventTypeRoaming, EventTypeDataSession, 0} {
+		e.EventType = eventType
+		if err := e.Validate(); err == nil {
+			t.Errorf("expected type %d out of configured ones to be rejected", int(eventType))
+		}
+		if _, err := ParseEventType(eventType.String()); err == nil {
+			t.Errorf("expected label of type %d out of configured ones to be rejected", int(eventType))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Events is a list of events with common operations on them.
+type Events []*Event
+
+// FilterByType returns events of provided type.
+func (events Events) FilterByType(t EventType) Events {
+	return events.Filter(func(e *Event) bool { return e.EventType == t })
+}
+
+// Filter returns events for which keep returns true.
+func (events Events) Filter(keep func(e *Event) bool) Events {
+	var filtered Events
+	for _, e := range events {
+		if keep(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// CountByType returns number of events of every event type.
+func (events Events) CountByType() map[EventType]int {
+	counts := map[EventType]int{}
+	for _, e := range events {
+		counts[e.EventType]++
+	}
+	return counts
+}
+
+// SortByDate sorts events by event date in place, order of events with the same date is kept.
+func (events Events) SortByDate() {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].EventDate.Before(events[j].EventDate)
+	})
+}
+
+// EarliestLatest returns dates of the earliest and the latest events, zero times for empty events.
+func (events Events) EarliestLatest() (time.Time, time.Time) {
+	if len(events) == 0 {
+		return time.Time{}, time.Time{}
+	}
+
+	earliest, latest := events[0].EventDate, events[0].EventDate
+	for _, e := range events[1:] {
+		if e.EventDate.Before(earliest) {
+			earliest = e.EventDate
+		}
+		if e.EventDate.After(latest) {
+			latest = e.EventDate
+		}
+	}
+	return earliest, latest
+}
+
+// CloneAll returns copies of all the events, so derived events could be changed without affecting provided ones.
+func CloneAll(events Events) Events {
+	if events == nil {
+		return nil
+	}
+
+	clones := make(Events, 0, len(events))
+	for _, e := range events {
+		clones = append(clones, e.Clone())
+	}
+	return clones
+}
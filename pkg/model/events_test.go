@@ -0,0 +1,125 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCloneAllIsolatesMutations(t *testing.T) {
+	first, second := testEvent(), testEvent()
+	second.EventRef = "second"
+	events := Events{first, second}
+
+	clones := CloneAll(events)
+	if len(clones) != len(events) {
+		t.Fatalf("expected %d clones, got %d", len(events), len(clones))
+	}
+	for i := range events {
+		if clones[i] == events[i] || *clones[i] != *events[i] {
+			t.Errorf("event %d : expected a copy of %+v, got %+v", i, *events[i], *clones[i])
+		}
+	}
+
+	clones[0].Location = "changed"
+	clones[1] = nil
+	if first.Location == "changed" || events[1] != second {
+		t.Errorf("expected original events to stay unchanged, got %+v", events)
+	}
+}
+
+func TestCloneAllOfNil(t *testing.T) {
+	if clones := CloneAll(nil); clones != nil {
+		t.Errorf("expected nil clones, got %v", clones)
+	}
+	if clones := CloneAll(Events{}); clones == nil || len(clones) != 0 {
+		t.Errorf("expected empty clones, got %v", clones)
+	}
+}
+
+// datedEvents returns events of provided types dated by provided hours of 2022-03-14.
+func datedEvents(types []EventType, hours []int) Events {
+	events := make(Events, 0, len(types))
+	for i, t := range types {
+		e := testEvent()
+		e.EventRef = string(rune('a' + i))
+		e.EventType = t
+		e.EventDate = time.Date(2022, 3, 14, hours[i], 0, 0, 0, time.UTC)
+		events = append(events, e)
+	}
+	return events
+}
+
+// refs returns refs of events in order.
+func refs(events Events) string {
+	var s string
+	for _, e := range events {
+		s += e.EventRef
+	}
+	return s
+}
+
+func TestFilterByType(t *testing.T) {
+	events := datedEvents([]EventType{EventTypeSMS, EventTypeDataSession, EventTypeSMS, EventTypeStandardCall}, []int{1, 2, 3, 4})
+
+	tests := []struct {
+		eventType EventType
+		expected  string
+	}{
+		{eventType: EventTypeSMS, expected: "ac"},
+		{eventType: EventTypeDataSession, expected: "b"},
+		{eventType: EventTypeRoaming},
+	}
+	for _, test := range tests {
+		if actual := refs(events.FilterByType(test.eventType)); actual != test.expected {
+			t.Errorf("%v : expected events %q, got %q", test.eventType, test.expected, actual)
+		}
+	}
+
+	if filtered := (Events{}).FilterByType(EventTypeSMS); len(filtered) != 0 {
+		t.Errorf("expected no events of empty events, got %v", filtered)
+	}
+}
+
+func TestCountByType(t *testing.T) {
+	events := datedEvents([]EventType{EventTypeSMS, EventTypeDataSession, EventTypeSMS, EventTypeSMS}, []int{1, 2, 3, 4})
+
+	counts := events.CountByType()
+	if len(counts) != 2 || counts[EventTypeSMS] != 3 || counts[EventTypeDataSession] != 1 {
+		t.Errorf("expected 3 sms and 1 data session, got %v", counts)
+	}
+	if counts := (Events{}).CountByType(); len(counts) != 0 {
+		t.Errorf("expected no counts of empty events, got %v", counts)
+	}
+}
+
+func TestSortByDate(t *testing.T) {
+	types := []EventType{EventTypeSMS, EventTypeSMS, EventTypeSMS, EventTypeSMS, EventTypeSMS}
+	events := datedEvents(types, []int{5, 1, 3, 1, 2})
+
+	events.SortByDate()
+	// events of the same date keep their order
+	if actual := refs(events); actual != "bdeca" {
+		t.Errorf("expected events sorted by date, got %q", actual)
+	}
+}
+
+func TestEarliestLatest(t *testing.T) {
+	types := []EventType{EventTypeSMS, EventTypeSMS, EventTypeSMS}
+	date := func(hour int) time.Time { return time.Date(2022, 3, 14, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name             string
+		events           Events
+		earliest, latest time.Time
+	}{
+		{name: "no events"},
+		{name: "single event", events: datedEvents(types[:1], []int{7}), earliest: date(7), latest: date(7)},
+		{name: "unsorted events", events: datedEvents(types, []int{5, 1, 9}), earliest: date(1), latest: date(9)},
+	}
+	for _, test := range tests {
+		earliest, latest := test.events.EarliestLatest()
+		if !earliest.Equal(test.earliest) || !latest.Equal(test.latest) {
+			t.Errorf("%s : expected %v - %v, got %v - %v", test.name, test.earliest, test.latest, earliest, latest)
+		}
+	}
+}
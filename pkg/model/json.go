@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// CamelCase converts snake_case json name of event field to camelCase, e.g. event_ref to eventRef and attr_1 to attr1.
+func CamelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// plainEvent is event without json methods, so it's decoded with default snake_case keys.
+type plainEvent Event
+
+// eventKeys are keys of event accepted by decoding, snake_case keys like event_ref are decoded into the embedded
+// event and camelCase keys like eventRef, as written by generator with -json-case camel, into the rest of fields.
+type eventKeys struct {
+	plainEvent
+	CamelEventSource     int       `json:"eventSource"`
+	CamelEventRef        string    `json:"eventRef"`
+	CamelEventType       EventType `json:"eventType"`
+	CamelEventDate       time.Time `json:"eventDate"`
+	CamelCallingNumber   int       `json:"callingNumber"`
+	CamelCalledNumber    int       `json:"calledNumber"`
+	CamelDurationSeconds int       `json:"durationSeconds"`
+	CamelDurationMillis  int       `json:"durationMillis"`
+	CamelAttr1           string    `json:"attr1"`
+	CamelAttr2           string    `json:"attr2"`
+	CamelAttr3           string    `json:"attr3"`
+	CamelAttr4           string    `json:"attr4"`
+	CamelAttr5           string    `json:"attr5"`
+	CamelAttr6           string    `json:"attr6"`
+	CamelAttr7           string    `json:"attr7"`
+	CamelAttr8           string    `json:"attr8"`
+}
+
+// UnmarshalJSON decodes event with either snake_case or camelCase keys in a single pass,
+// snake_case value is used if a field is present in both cases. Location is the same in both cases.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var keys eventKeys
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	*e = Event(keys.plainEvent)
+	setInt(&e.EventSource, keys.CamelEventSource)
+	setString(&e.EventRef, keys.CamelEventRef)
+	if e.EventType == 0 {
+		e.EventType = keys.CamelEventType
+	}
+	if e.EventDate.IsZero() {
+		e.EventDate = keys.CamelEventDate
+	}
+	setInt(&e.CallingNumber, keys.CamelCallingNumber)
+	setInt(&e.CalledNumber, keys.CamelCalledNumber)
+	setInt(&e.DurationSeconds, keys.CamelDurationSeconds)
+	setInt(&e.DurationMillis, keys.CamelDurationMillis)
+	setString(&e.Attr1, keys.CamelAttr1)
+	setString(&e.Attr2, keys.CamelAttr2)
+	setString(&e.Attr3, keys.CamelAttr3)
+	setString(&e.Attr4, keys.CamelAttr4)
+	setString(&e.Attr5, keys.CamelAttr5)
+	setString(&e.Attr6, keys.CamelAttr6)
+	setString(&e.Attr7, keys.CamelAttr7)
+	setString(&e.Attr8, keys.CamelAttr8)
+	return nil
+}
+
+// setInt sets field to camelCase value if snake_case one is not set.
+func setInt(field *int, camel int) {
+	if *field == 0 {
+		*field = camel
+	}
+}
+
+// setString sets field to camelCase value if snake_case one is not set.
+func setString(field *string, camel string) {
+	if *field == "" {
+		*field = camel
+	}
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testEvent returns event with all the fields set.
+func testEvent() *Event {
+	return &Event{
+		EventSource:     79001234567,
+		EventRef:        "0b6a6c4e-3f0e-4e8c-9a57-1f2b3c4d5e6f",
+		EventType:       EventTypeSMS,
+		EventDate:       time.Date(2022, 3, 14, 15, 9, 26, 535, time.UTC),
+		CallingNumber:   79001234567,
+		CalledNumber:    79007654321,
+		Location:        "ABC",
+		DurationSeconds: 12,
+		DurationMillis:  12345,
+		Attr1:           "a1",
+		Attr2:           "a2",
+		Attr3:           "a3",
+		Attr4:           "a4",
+		Attr5:           "a5",
+		Attr6:           "a6",
+		Attr7:           "a7",
+		Attr8:           "a8",
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"event_ref":        "eventRef",
+		"duration_seconds": "durationSeconds",
+		"attr_1":           "attr1",
+		"location":         "location",
+	} {
+		if actual := CamelCase(name); actual != expected {
+			t.Errorf("%s : expected %s, got %s", name, expected, actual)
+		}
+	}
+}
+
+func TestSnakeCaseRoundTrip(t *testing.T) {
+	e := testEvent()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("unable to marshal : %v", err)
+	}
+	if !strings.Contains(string(data), `"event_ref":`) {
+		t.Fatalf("expected snake_case keys, got %s", data)
+	}
+
+	var decoded Event
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unable to unmarshal : %v", err)
+	}
+	if decoded != *e {
+		t.Errorf("expected %+v, got %+v", *e, decoded)
+	}
+}
+
+func TestCamelCaseRoundTrip(t *testing.T) {
+	e := testEvent()
+
+	p, err := NewProjection(append(FieldNames(), "duration_millis"))
+	if err != nil {
+		t.Fatalf("unable to create projection : %v", err)
+	}
+	data, err := p.CamelCase().JSON(e)
+	if err != nil {
+		t.Fatalf("unable to marshal : %v", err)
+	}
+	if !strings.Contains(string(data), `"eventRef":`) {
+		t.Fatalf("expected camelCase keys, got %s", data)
+	}
+
+	var decoded Event
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unable to unmarshal : %v", err)
+	}
+	if decoded != *e {
+		t.Errorf("expected %+v, got %+v", *e, decoded)
+	}
+}
+
+func TestUnmarshalAcceptsBothCases(t *testing.T) {
+	e := testEvent()
+
+	p, err := NewProjection(append(FieldNames(), "duration_millis"))
+	if err != nil {
+		t.Fatalf("unable to create projection : %v", err)
+	}
+	snake, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("unable to marshal : %v", err)
+	}
+	camel, err := p.CamelCase().JSON(e)
+	if err != nil {
+		t.Fatalf("unable to marshal : %v", err)
+	}
+
+	for name, data := range map[string][]byte{"snake": snake, "camel": camel} {
+		var decoded Event
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s : unable to unmarshal : %v", name, err)
+		}
+		if decoded != *e {
+			t.Errorf("%s : expected %+v, got %+v", name, *e, decoded)
+		}
+	}
+
+	// snake_case value wins if a field is present in both cases
+	var decoded Event
+	if err := json.Unmarshal([]byte(`{"event_ref":"snake","eventRef":"camel","attr1":"a1","callingNumber":7}`), &decoded); err != nil {
+		t.Fatalf("unable to unmarshal : %v", err)
+	}
+	if decoded.EventRef != "snake" || decoded.Attr1 != "a1" || decoded.CallingNumber != 7 {
+		t.Errorf("expected snake ref and camel attr1 and calling number, got %+v", decoded)
+	}
+}
+
+func TestUnmarshalRejectsMalformed(t *testing.T) {
+	var decoded Event
+	if err := json.Unmarshal([]byte(`{"event_type":"sms"}`), &decoded); err == nil {
+		t.Errorf("expected malformed event type to be rejected, got %+v", decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"eventDate":"yesterday"}`), &decoded); err == nil {
+		t.Errorf("expected malformed event date to be rejected, got %+v", decoded)
+	}
+}
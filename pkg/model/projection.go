@@ -0,0 +1,123 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Projection selects subset of event fields for serialization, fields are named as json fields.
+type Projection struct {
+	names []string
+	// keys are json keys of selected fields.
+	keys []string
+	// fields are indexes of selected fields in Event struct.
+	fields []int
+	// columns are indexes of selected fields in CSV record.
+	columns []int
+}
+
+// NewProjection creates projection of provided fields, unknown field names are rejected.
+func NewProjection(names []string) (*Projection, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("at least one field must be selected")
+	}
+
+	fields := eventFields()
+	p := &Projection{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown event field '%s'", name)
+		}
+
+		column := -1
+		for i, header := range CSVHeader {
+			if header == name {
+				column = i
+			}
+		}
+
+		p.names = append(p.names, name)
+		p.keys = append(p.keys, name)
+		p.fields = append(p.fields, field)
+		p.columns = append(p.columns, column)
+	}
+	return p, nil
+}
+
+// FieldNames returns json names of all the event fields in order.
+func FieldNames() []string {
+	return append([]string(nil), CSVHeader...)
+}
+
+// CamelCase returns copy of the projection writing json keys in camelCase, e.g. eventRef instead of event_ref.
+func (p *Projection) CamelCase() *Projection {
+	camel := *p
+	camel.keys = make([]string, 0, len(p.names))
+	for _, name := range p.names {
+		camel.keys = append(camel.keys, CamelCase(name))
+	}
+	return &camel
+}
+
+// eventFields returns indexes of Event struct fields by their json names.
+func eventFields() map[string]int {
+	t := reflect.TypeOf(Event{})
+
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+// Header returns CSV header of projected fields.
+func (p *Projection) Header() []string {
+	return p.names
+}
+
+// CSVRecord returns projected CSV record of event.
+func (p *Projection) CSVRecord(e *Event) []string {
+	full := e.CSVRecord()
+
+	record := make([]string, 0, len(p.columns))
+	for i, column := range p.columns {
+		// optional fields are not CSV columns
+		if column < 0 {
+			record = append(record, fmt.Sprint(reflect.ValueOf(e).Elem().Field(p.fields[i]).Interface()))
+			continue
+		}
+		record = append(record, full[column])
+	}
+	return record
+}
+
+// JSON returns json object of projected fields keeping order of the projection.
+func (p *Projection) JSON(e *Event) ([]byte, error) {
+	v := reflect.ValueOf(e).Elem()
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, field := range p.fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		value, err := json.Marshal(v.Field(field).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshall %s : %w", p.names[i], err)
+		}
+		fmt.Fprintf(&b, "%q:", p.keys[i])
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Validate checks that event could be loaded and rated, all the problems are reported at once.
+func (e *Event) Validate() error {
+	var problems []string
+
+	if e.EventSource < 0 {
+		problems = append(problems, fmt.Sprintf("negative event source %d", e.EventSource))
+	}
+	if _, err := uuid.Parse(e.EventRef); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid event ref '%s'", e.EventRef))
+	}
+	if !e.EventType.IsValid() {
+		problems = append(problems, fmt.Sprintf("unknown event type %d", int(e.EventType)))
+	}
+	if e.EventDate.IsZero() {
+		problems = append(problems, "missing event date")
+	}
+	if e.CallingNumber < 0 {
+		problems = append(problems, fmt.Sprintf("negative calling number %d", e.CallingNumber))
+	}
+	if e.CalledNumber < 0 {
+		problems = append(problems, fmt.Sprintf("negative called number %d", e.CalledNumber))
+	}
+	if e.Location == "" {
+		problems = append(problems, "missing location")
+	}
+	if e.DurationSeconds < 0 {
+		problems = append(problems, fmt.Sprintf("negative duration %d", e.DurationSeconds))
+	}
+	if e.DurationMillis < 0 {
+		problems = append(problems, fmt.Sprintf("negative duration %dms", e.DurationMillis))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid event %s : %s", e.EventRef, strings.Join(problems, ", "))
+	}
+	return nil
+}
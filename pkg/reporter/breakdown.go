@@ -0,0 +1,75 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+// EventTypeBreakdown returns observed percentage of events of every event type.
+// Percentages are keyed by model.EventType rather than plain int, so they match Distribution.Percentages
+// and print with type labels, int(t) gives the numeric type.
+func EventTypeBreakdown(events []*model.Event) map[model.EventType]float64 {
+	return CountsBreakdown(model.Events(events).CountByType())
+}
+
+// CountsBreakdown returns percentage of events of every event type from numbers of events by type,
+// so breakdown of a stream is computed without keeping its events.
+func CountsBreakdown(counts map[model.EventType]int) map[model.EventType]float64 {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	breakdown := map[model.EventType]float64{}
+	for t, count := range counts {
+		breakdown[t] = float64(count) / float64(total) * 100
+	}
+	return breakdown
+}
+
+// PrintEventTypeBreakdown will print expected and actual percentage of every event type next to each other.
+// Types present only in one of the maps are printed as well.
+func PrintEventTypeBreakdown(w io.Writer, expected, actual map[model.EventType]float64) {
+	types := sortedTypes(expected, actual)
+
+	fmt.Fprintf(w, "%-20s %10s %10s %10s\n", "type", "expected", "actual", "diff")
+	for _, t := range types {
+		label := fmt.Sprintf("%d (%s)", int(t), t)
+		fmt.Fprintf(w, "%-20s %9.2f%% %9.2f%% %+9.2f%%\n", label, expected[t], actual[t], actual[t]-expected[t])
+	}
+}
+
+// CheckDistribution returns error listing event types which actual percentage differs from expected one
+// by more than tolerance percentage points, types present only in one of the maps are checked as well.
+func CheckDistribution(expected, actual map[model.EventType]float64, tolerance float64) error {
+	var deviations []string
+	for _, t := range sortedTypes(expected, actual) {
+		if diff := actual[t] - expected[t]; math.Abs(diff) > tolerance {
+			deviations = append(deviations, fmt.Sprintf("%d (%s) %+.2f%%", int(t), t, diff))
+		}
+	}
+	if len(deviations) > 0 {
+		return fmt.Errorf("event types deviate from distribution by more than %.2f%% : %s", tolerance, strings.Join(deviations, ", "))
+	}
+	return nil
+}
+
+// sortedTypes returns event types of both maps in ascending order.
+func sortedTypes(expected, actual map[model.EventType]float64) []model.EventType {
+	types := make([]model.EventType, 0, len(expected))
+	for t := range expected {
+		types = append(types, t)
+	}
+	for t := range actual {
+		if _, ok := expected[t]; !ok {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
@@ -0,0 +1,155 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dmgo1014/interviewing-golang.git/pkg/model"
+)
+
+func TestCountsBreakdown(t *testing.T) {
+	counts := map[model.EventType]int{model.EventTypeStandardCall: 1, model.EventTypeSMS: 3}
+
+	breakdown := CountsBreakdown(counts)
+	expected := map[model.EventType]float64{model.EventTypeStandardCall: 25, model.EventTypeSMS: 75}
+	if len(breakdown) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, breakdown)
+	}
+	for eventType, percentage := range expected {
+		if breakdown[eventType] != percentage {
+			t.Errorf("type %d : expected %.2f%%, got %.2f%%", eventType, percentage, breakdown[eventType])
+		}
+	}
+}
+
+func TestCountsBreakdownEmpty(t *testing.T) {
+	if breakdown := CountsBreakdown(nil); len(breakdown) != 0 {
+		t.Errorf("expected empty breakdown, got %v", breakdown)
+	}
+}
+
+// knownEvents returns 20 events: 2 standard calls, 4 sms, 5 roaming and 9 data sessions.
+func knownEvents() []*model.Event {
+	counts := []struct {
+		eventType model.EventType
+		count     int
+	}{
+		{model.EventTypeStandardCall, 2},
+		{model.EventTypeSMS, 4},
+		{model.EventTypeRoaming, 5},
+		{model.EventTypeDataSession, 9},
+	}
+
+	var events []*model.Event
+	for _, c := range counts {
+		for i := 0; i < c.count; i++ {
+			events = append(events, &model.Event{EventType: c.eventType})
+		}
+	}
+	return events
+}
+
+func TestEventTypeBreakdown(t *testing.T) {
+	breakdown := EventTypeBreakdown(knownEvents())
+
+	expected := map[model.EventType]float64{
+		model.EventTypeStandardCall: 10,
+		model.EventTypeSMS:          20,
+		model.EventTypeRoaming:      25,
+		model.EventTypeDataSession:  45,
+	}
+	if len(breakdown) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, breakdown)
+	}
+	for eventType, percentage := range expected {
+		if breakdown[eventType] != percentage {
+			t.Errorf("type %d : expected %.2f%%, got %.2f%%", eventType, percentage, breakdown[eventType])
+		}
+	}
+}
+
+func TestPrintEventTypeBreakdown(t *testing.T) {
+	expected := map[model.EventType]float64{
+		model.EventTypeStandardCall:   10,
+		model.EventTypeSMS:            20,
+		model.EventTypePremiumService: 10,
+		model.EventTypeDataSession:    60,
+	}
+
+	var buf bytes.Buffer
+	PrintEventTypeBreakdown(&buf, expected, EventTypeBreakdown(knownEvents()))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	// header and types present in any of the breakdowns in order
+	if len(lines) != 6 {
+		t.Fatalf("expected header and 5 types, got:\n%s", buf.String())
+	}
+	for i, prefix := range []string{"type", "1 (standard_call)", "2 (sms)", "3 (premium_service)", "4 (roaming)", "5 (data_session)"} {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d : expected %s, got %s", i, prefix, lines[i])
+		}
+	}
+	for i, suffix := range map[int]string{3: "-10.00%", 4: "+25.00%", 5: "-15.00%"} {
+		if !strings.HasSuffix(lines[i], suffix) {
+			t.Errorf("line %d : expected difference %s, got %s", i, suffix, lines[i])
+		}
+	}
+}
+
+// knownDistribution is distribution knownEvents are drawn from.
+func knownDistribution() map[model.EventType]float64 {
+	return map[model.EventType]float64{
+		model.EventTypeStandardCall: 10,
+		model.EventTypeSMS:          20,
+		model.EventTypeRoaming:      25,
+		model.EventTypeDataSession:  45,
+	}
+}
+
+func TestCheckDistributionPasses(t *testing.T) {
+	if err := CheckDistribution(knownDistribution(), EventTypeBreakdown(knownEvents()), 1); err != nil {
+		t.Errorf("expected matching breakdown to pass, got %v", err)
+	}
+
+	// deviations within tolerance are accepted
+	actual := knownDistribution()
+	actual[model.EventTypeSMS] += 0.9
+	actual[model.EventTypeDataSession] -= 0.9
+	if err := CheckDistribution(knownDistribution(), actual, 1); err != nil {
+		t.Errorf("expected deviation within tolerance to pass, got %v", err)
+	}
+}
+
+func TestCheckDistributionFailsOnSkewedStub(t *testing.T) {
+	// stub generating sms instead of 4 of 9 data sessions
+	skewed := knownEvents()
+	for i, e := range skewed {
+		if e.EventType == model.EventTypeDataSession && i%2 == 0 {
+			e.EventType = model.EventTypeSMS
+		}
+	}
+
+	err := CheckDistribution(knownDistribution(), EventTypeBreakdown(skewed), 1)
+	if err == nil {
+		t.Fatal("expected skewed breakdown to fail the check")
+	}
+	for _, deviation := range []string{"2 (sms) +20.00%", "5 (data_session) -20.00%"} {
+		if !strings.Contains(err.Error(), deviation) {
+			t.Errorf("expected %s deviation to be reported, got %v", deviation, err)
+		}
+	}
+	if strings.Contains(err.Error(), "roaming") {
+		t.Errorf("expected types within tolerance not to be reported, got %v", err)
+	}
+}
+
+func TestCheckDistributionFailsOnUnexpectedType(t *testing.T) {
+	actual := EventTypeBreakdown(append(knownEvents(), &model.Event{EventType: model.EventTypePremiumService}))
+	if err := CheckDistribution(knownDistribution(), actual, 10); err != nil {
+		t.Errorf("expected deviation within tolerance to pass, got %v", err)
+	}
+	if err := CheckDistribution(knownDistribution(), actual, 1); err == nil || !strings.Contains(err.Error(), "premium_service") {
+		t.Errorf("expected unexpected type to be reported, got %v", err)
+	}
+}
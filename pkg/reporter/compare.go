@@ -0,0 +1,72 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Compare will print to Output side by side comparison of median durations stored in two statistic files.
+// Records are matched by number of events, file A is treated as a baseline for file B.
+// Numbers of events present only in one of the files are marked as N/A.
+// ErrStatisticsNotFound is returned if any of the files does not exist.
+func Compare(fileA, fileB string) error {
+	statsA, err := readStatistics(fileA)
+	if err != nil {
+		return err
+	}
+	statsB, err := readStatistics(fileB)
+	if err != nil {
+		return err
+	}
+
+	mediansA := mediansByNumbOfEvents(statsA)
+	mediansB := mediansByNumbOfEvents(statsB)
+
+	var counts []int
+	for count := range mediansA {
+		counts = append(counts, count)
+	}
+	for count := range mediansB {
+		if _, ok := mediansA[count]; !ok {
+			counts = append(counts, count)
+		}
+	}
+	sort.Ints(counts)
+
+	fmt.Fprintf(Output, "A : %s\n", fileA)
+	fmt.Fprintf(Output, "B : %s\n", fileB)
+	fmt.Fprintf(Output, "%15s | %15s | %15s | %s\n", "Events", "Median A", "Median B", "B vs A")
+	for _, count := range counts {
+		a, okA := mediansA[count]
+		b, okB := mediansB[count]
+
+		medianA, medianB, improvement := "N/A", "N/A", "N/A"
+		if okA {
+			medianA = a.String()
+		}
+		if okB {
+			medianB = b.String()
+		}
+		if okA && okB {
+			improvement = calculateImprovement(a, b, Colors)
+		}
+
+		fmt.Fprintf(Output, "%15s | %15s | %15s | %s\n", FormatNumber(count), medianA, medianB, improvement)
+	}
+	return nil
+}
+
+// mediansByNumbOfEvents returns median duration of runs grouped by number of events.
+func mediansByNumbOfEvents(stats []ExecutionStatistic) map[int]time.Duration {
+	grouped := map[int][]ExecutionStatistic{}
+	for _, s := range stats {
+		grouped[s.NumbOfEvents] = append(grouped[s.NumbOfEvents], s)
+	}
+
+	medians := make(map[int]time.Duration, len(grouped))
+	for count, group := range grouped {
+		medians[count] = Summarize(group).Median
+	}
+	return medians
+}
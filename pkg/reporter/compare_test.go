@@ -0,0 +1,50 @@
+package reporter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	withFormatting(t, false, ",")
+	defer func(w io.Writer) { Output = w }(Output)
+	var buf bytes.Buffer
+	Output = &buf
+
+	dir := t.TempDir()
+	fileA, fileB := filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")
+	writeStatistics(t, fileA, []ExecutionStatistic{
+		{NumbOfEvents: 1000, Duration: 20 * time.Millisecond},
+		{NumbOfEvents: 1000000, Duration: 4 * time.Second},
+	})
+	writeStatistics(t, fileB, []ExecutionStatistic{
+		{NumbOfEvents: 1000, Duration: 10 * time.Millisecond},
+		{NumbOfEvents: 5000, Duration: time.Second},
+	})
+
+	if err := Compare(fileA, fileB); err != nil {
+		t.Fatalf("unable to compare statistics : %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected header and 3 numbers of events, got:\n%s", buf.String())
+	}
+	for i, expected := range []string{"1,000 |", "5,000 |", "1,000,000 |"} {
+		if line := strings.TrimSpace(lines[i+3]); !strings.HasPrefix(line, expected) {
+			t.Errorf("expected line starting with %q, got %q", expected, line)
+		}
+	}
+	if !strings.Contains(lines[4], "N/A") || !strings.Contains(lines[5], "N/A") {
+		t.Errorf("expected numbers of events present in one file to be N/A, got:\n%s", buf.String())
+	}
+
+	if err := Compare(fileA, filepath.Join(dir, "missing.json")); !errors.Is(err, ErrStatisticsNotFound) {
+		t.Errorf("expected statistics not found error, got %v", err)
+	}
+}
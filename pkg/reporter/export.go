@@ -0,0 +1,41 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ExportJSON will write statistics as a pretty-printed json array, empty statistics are written as [].
+func ExportJSON(stats []ExecutionStatistic, w io.Writer) error {
+	if stats == nil {
+		stats = []ExecutionStatistic{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(stats); err != nil {
+		return fmt.Errorf("unable to write statistics : %w", err)
+	}
+	return nil
+}
+
+// ExportJSONFile will export all the statistics of provided statistics file to output file as a json array.
+func ExportJSONFile(filename, output string) error {
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("unable to create output file : %w", err)
+	}
+	defer file.Close()
+
+	if err := ExportJSON(stats, file); err != nil {
+		return err
+	}
+	return file.Sync()
+}
@@ -0,0 +1,59 @@
+package reporter
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportJSON(testStatistics(), &buf); err != nil {
+		t.Fatalf("unable to export statistics : %v", err)
+	}
+	assertGolden(t, "export.golden.json", buf.Bytes())
+}
+
+func TestExportJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportJSON(nil, &buf); err != nil {
+		t.Fatalf("unable to export statistics : %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("expected empty array, got %q", buf.String())
+	}
+}
+
+func TestExportJSONFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "stats.json")
+	for _, stat := range testStatistics() {
+		if err := Save(filename, stat); err != nil {
+			t.Fatalf("unable to save statistic : %v", err)
+		}
+	}
+
+	output := filepath.Join(dir, "export.json")
+	if err := ExportJSONFile(filename, output); err != nil {
+		t.Fatalf("unable to export statistics : %v", err)
+	}
+
+	// statistics without procs are saved with the current ones, so the file is compared with saved statistics
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+	var expected bytes.Buffer
+	if err := ExportJSON(stats, &expected); err != nil {
+		t.Fatalf("unable to export statistics : %v", err)
+	}
+
+	actual, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("unable to read export : %v", err)
+	}
+	if !bytes.Equal(expected.Bytes(), actual) {
+		t.Errorf("expected:\n%s\nactual:\n%s", expected.Bytes(), actual)
+	}
+}
@@ -0,0 +1,104 @@
+package reporter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatNumber(t *testing.T) {
+	withFormatting(t, false, ",")
+
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{n: 0, expected: "0"},
+		{n: 1, expected: "1"},
+		{n: 999, expected: "999"},
+		{n: 1000, expected: "1,000"},
+		{n: 999999, expected: "999,999"},
+		{n: 1000000, expected: "1,000,000"},
+		{n: -1, expected: "-1"},
+		{n: -100, expected: "-100"},
+		{n: -999, expected: "-999"},
+		{n: -1000, expected: "-1,000"},
+		{n: -999999, expected: "-999,999"},
+		{n: -1000000, expected: "-1,000,000"},
+		{n: math.MaxInt64, expected: "9,223,372,036,854,775,807"},
+		{n: math.MinInt64, expected: "-9,223,372,036,854,775,808"},
+	}
+
+	for _, test := range tests {
+		if actual := FormatNumber(test.n); actual != test.expected {
+			t.Errorf("%d : expected '%s', got '%s'", test.n, test.expected, actual)
+		}
+	}
+}
+
+func TestFormatNumberLocale(t *testing.T) {
+	tests := []struct {
+		n        int
+		sep      string
+		expected string
+	}{
+		{n: 1234567, sep: ",", expected: "1,234,567"},
+		{n: 1234567, sep: " ", expected: "1 234 567"},
+		{n: 1234567, sep: ".", expected: "1.234.567"},
+		{n: 1234567, sep: "", expected: "1234567"},
+		{n: 1234567, sep: " ", expected: "1 234 567"},
+		{n: 999, sep: " ", expected: "999"},
+		{n: 1000, sep: ".", expected: "1.000"},
+		{n: -1234567, sep: " ", expected: "-1 234 567"},
+		{n: -1000, sep: ".", expected: "-1.000"},
+	}
+
+	for _, test := range tests {
+		if actual := FormatNumberLocale(test.n, test.sep); actual != test.expected {
+			t.Errorf("%d with '%s' : expected '%s', got '%s'", test.n, test.sep, test.expected, actual)
+		}
+	}
+}
+
+func TestLocaleSeparator(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: "", expected: ","},
+		{locale: "C", expected: ","},
+		{locale: "POSIX", expected: ","},
+		{locale: "en_US.UTF-8", expected: ","},
+		{locale: "de_DE.UTF-8", expected: "."},
+		{locale: "DE", expected: "."},
+		{locale: "pt-BR", expected: "."},
+		{locale: "fr_FR", expected: " "},
+		{locale: "uk_UA.UTF-8", expected: " "},
+		{locale: "ru_RU@euro", expected: " "},
+	}
+
+	for _, test := range tests {
+		if actual := LocaleSeparator(test.locale); actual != test.expected {
+			t.Errorf("%s : expected '%s', got '%s'", test.locale, test.expected, actual)
+		}
+	}
+}
+
+func TestNumericLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "de_DE.UTF-8")
+	if locale := numericLocale(); locale != "de_DE.UTF-8" {
+		t.Errorf("expected LC_NUMERIC locale, got '%s'", locale)
+	}
+
+	t.Setenv("LC_ALL", "fr_FR.UTF-8")
+	if locale := numericLocale(); locale != "fr_FR.UTF-8" {
+		t.Errorf("expected LC_ALL to override LC_NUMERIC, got '%s'", locale)
+	}
+}
+
+func TestFormatNumberUsesSeparator(t *testing.T) {
+	withFormatting(t, false, " ")
+	if actual := FormatNumber(1234567); actual != "1 234 567" {
+		t.Errorf("expected '1 234 567', got '%s'", actual)
+	}
+}
@@ -0,0 +1,61 @@
+package reporter
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// update makes golden tests rewrite golden files with the actual output.
+var update = flag.Bool("update", false, "update golden files of testdata")
+
+// ansiColors matches color escape sequences.
+var ansiColors = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// stripColors removes color escape sequences of the output.
+func stripColors(s string) string {
+	return ansiColors.ReplaceAllString(s, "")
+}
+
+// assertGolden fails the test if actual output differs from the content of golden file of testdata.
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	golden := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(golden, actual, 0644); err != nil {
+			t.Fatalf("unable to update golden file : %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("unable to read golden file : %v", err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("output differs from %s, run go test -update to accept it\nexpected:\n%s\nactual:\n%s", golden, expected, actual)
+	}
+}
+
+// withFormatting sets colors and thousands separator for the test, package defaults depend on terminal and locale.
+func withFormatting(t *testing.T, colors bool, separator string) {
+	t.Helper()
+	prevColors, prevSeparator := Colors, Separator
+	t.Cleanup(func() { Colors, Separator = prevColors, prevSeparator })
+	Colors, Separator = colors, separator
+}
+
+// testStatistics returns statistics of runs of two sizes, every size is run several times.
+func testStatistics() []ExecutionStatistic {
+	start := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+	return []ExecutionStatistic{
+		{ExecutionStart: start, NumbOfEvents: 1000000, Duration: 4 * time.Second, GitCommit: "abc1234", Hostname: "host-1", GoMaxProcs: 8, NumCPU: 8},
+		{ExecutionStart: start.Add(time.Hour), NumbOfEvents: 1000, Duration: 20 * time.Millisecond},
+		{ExecutionStart: start.Add(2 * time.Hour), NumbOfEvents: 1000000, Duration: 3 * time.Second, GoMaxProcs: 8, NumCPU: 8},
+		{ExecutionStart: start.Add(3 * time.Hour), NumbOfEvents: 1000000, Duration: 5 * time.Second, GoMaxProcs: 4, NumCPU: 8},
+		{ExecutionStart: start.Add(4 * time.Hour), NumbOfEvents: 1000, Duration: 10 * time.Millisecond},
+	}
+}
@@ -0,0 +1,77 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// histogramWidth is the length of the longest bar in histogram.
+const histogramWidth = 40
+
+// Histogram returns ASCII bar chart of durations distribution split into provided number of buckets.
+// Statistics are expected to be filtered by number of events, see FilterByNumbOfEvents.
+// If all the durations are equal a single bucket is returned.
+func Histogram(stats []ExecutionStatistic, buckets int) string {
+	if len(stats) == 0 || buckets <= 0 {
+		return ""
+	}
+
+	min, max := stats[0].Duration, stats[0].Duration
+	for _, s := range stats {
+		if s.Duration < min {
+			min = s.Duration
+		}
+		if s.Duration > max {
+			max = s.Duration
+		}
+	}
+
+	if min == max {
+		buckets = 1
+	}
+	width := (max - min) / time.Duration(buckets)
+	if width == 0 {
+		// range is narrower than number of buckets
+		width = 1
+		buckets = int(max-min) + 1
+	}
+
+	counts := make([]int, buckets)
+	maxCount := 0
+	for _, s := range stats {
+		i := int((s.Duration - min) / width)
+		if i >= buckets {
+			// max value belongs to the last bucket
+			i = buckets - 1
+		}
+		counts[i]++
+		if counts[i] > maxCount {
+			maxCount = counts[i]
+		}
+	}
+
+	var b strings.Builder
+	for i, count := range counts {
+		from := min + time.Duration(i)*width
+		to := from + width
+		if i == buckets-1 {
+			to = max
+		}
+
+		bar := strings.Repeat("#", count*histogramWidth/maxCount)
+		fmt.Fprintf(&b, "%14v - %-14v | %-*s %d\n", from, to, histogramWidth, bar, count)
+	}
+	return b.String()
+}
+
+// FilterByNumbOfEvents returns statistics of runs with provided number of events.
+func FilterByNumbOfEvents(stats []ExecutionStatistic, numbOfEvents int) []ExecutionStatistic {
+	var filtered []ExecutionStatistic
+	for _, s := range stats {
+		if s.NumbOfEvents == numbOfEvents {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
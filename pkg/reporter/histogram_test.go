@@ -0,0 +1,70 @@
+package reporter
+
+import (
+	"testing"
+	"time"
+)
+
+// runs returns statistics of runs of 1000 events with provided durations.
+func runs(durations ...time.Duration) []ExecutionStatistic {
+	stats := make([]ExecutionStatistic, 0, len(durations))
+	for _, d := range durations {
+		stats = append(stats, ExecutionStatistic{NumbOfEvents: 1000, Duration: d})
+	}
+	return stats
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    []ExecutionStatistic
+		buckets  int
+		expected string
+	}{
+		{name: "no runs", buckets: 3},
+		{name: "no buckets", stats: runs(time.Second), buckets: 0},
+		{
+			name:     "single run",
+			stats:    runs(time.Second),
+			buckets:  3,
+			expected: "            1s - 1s             | ######################################## 1\n",
+		},
+		{
+			name:     "equal durations",
+			stats:    runs(time.Second, time.Second, time.Second),
+			buckets:  3,
+			expected: "            1s - 1s             | ######################################## 3\n",
+		},
+		{
+			name:    "spread durations",
+			stats:   runs(time.Second, 2*time.Second, 2*time.Second, 4*time.Second),
+			buckets: 3,
+			expected: "            1s - 2s             | ####################                     1\n" +
+				"            2s - 3s             | ######################################## 2\n" +
+				"            3s - 4s             | ####################                     1\n",
+		},
+		{
+			name:    "range narrower than buckets",
+			stats:   runs(1, 2),
+			buckets: 3,
+			expected: "           1ns - 2ns            | ######################################## 1\n" +
+				"           2ns - 2ns            | ######################################## 1\n",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := Histogram(test.stats, test.buckets); actual != test.expected {
+			t.Errorf("%s : expected:\n%s\nactual:\n%s", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestFilterByNumbOfEvents(t *testing.T) {
+	filtered := FilterByNumbOfEvents(testStatistics(), 1000)
+	if len(filtered) != 2 || filtered[0].Duration != 20*time.Millisecond || filtered[1].Duration != 10*time.Millisecond {
+		t.Errorf("expected 2 runs of 1000 events in order, got %+v", filtered)
+	}
+	if filtered := FilterByNumbOfEvents(testStatistics(), 5); len(filtered) != 0 {
+		t.Errorf("expected no runs of 5 events, got %+v", filtered)
+	}
+}
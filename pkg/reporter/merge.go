@@ -0,0 +1,70 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Merge will write statistics of all the input files to a single file ordered by execution start,
+// records with the same execution start and number of events are written once, malformed lines are skipped.
+// Output is written to a temporary file renamed at the end, so output could be one of the inputs.
+// ErrStatisticsNotFound is returned if any of input files does not exist.
+func Merge(outFile string, inFiles ...string) error {
+	type key struct {
+		start        int64
+		numbOfEvents int
+	}
+
+	seen := map[key]bool{}
+	var merged []ExecutionStatistic
+	for _, inFile := range inFiles {
+		stats, err := readStatistics(inFile)
+		if err != nil {
+			return err
+		}
+		for _, s := range stats {
+			k := key{start: s.ExecutionStart.UnixNano(), numbOfEvents: s.NumbOfEvents}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			merged = append(merged, s)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].ExecutionStart.Before(merged[j].ExecutionStart) })
+
+	tmp, err := os.CreateTemp(filepath.Dir(outFile), filepath.Base(outFile)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create statistics file : %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, s := range merged {
+		if err := enc.Encode(s); err != nil {
+			return fmt.Errorf("unable to write statistic : %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("unable to write statistics file : %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		return fmt.Errorf("unable to change statistics file mode : %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("unable to sync statistics file : %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to close statistics file : %w", err)
+	}
+	if err := os.Rename(tmp.Name(), outFile); err != nil {
+		return fmt.Errorf("unable to replace statistics file : %w", err)
+	}
+	return nil
+}
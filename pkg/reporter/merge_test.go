@@ -0,0 +1,145 @@
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeStatistics writes statistics to the file as newline delimited json followed by extra lines.
+func writeStatistics(t *testing.T, filename string, stats []ExecutionStatistic, extra ...string) {
+	t.Helper()
+	var lines []string
+	for _, s := range stats {
+		content, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("unable to marshall statistic : %v", err)
+		}
+		lines = append(lines, string(content))
+	}
+	lines = append(lines, extra...)
+	if err := os.WriteFile(filename, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("unable to write statistics : %v", err)
+	}
+}
+
+// assertStarts fails the test if statistics don't have expected execution starts in the same order.
+func assertStarts(t *testing.T, expected []time.Time, stats []ExecutionStatistic) {
+	t.Helper()
+	if len(stats) != len(expected) {
+		t.Fatalf("expected %d statistics, got %d : %+v", len(expected), len(stats), stats)
+	}
+	for i, s := range stats {
+		if !s.ExecutionStart.Equal(expected[i]) {
+			t.Errorf("statistic %d : expected start %v, got %v", i, expected[i], s.ExecutionStart)
+		}
+	}
+}
+
+func TestMergeOrdersByExecutionStart(t *testing.T) {
+	dir := t.TempDir()
+	stats := testStatistics()
+	first, second, out := filepath.Join(dir, "first.json"), filepath.Join(dir, "second.json"), filepath.Join(dir, "merged.json")
+	// files of different machines interleave and are not sorted themselves
+	writeStatistics(t, first, []ExecutionStatistic{stats[4], stats[0], stats[2]})
+	writeStatistics(t, second, []ExecutionStatistic{stats[3], stats[1]})
+
+	if err := Merge(out, first, second); err != nil {
+		t.Fatalf("unable to merge statistics : %v", err)
+	}
+
+	merged, err := GetAllStatistics(out)
+	if err != nil {
+		t.Fatalf("unable to read merged statistics : %v", err)
+	}
+	var expected []time.Time
+	for _, s := range stats {
+		expected = append(expected, s.ExecutionStart)
+	}
+	assertStarts(t, expected, merged)
+}
+
+func TestMergeDeduplicates(t *testing.T) {
+	dir := t.TempDir()
+	stats := testStatistics()
+	first, second, out := filepath.Join(dir, "first.json"), filepath.Join(dir, "second.json"), filepath.Join(dir, "merged.json")
+
+	// the same run with other duration is still a duplicate, the first record is kept
+	duplicate := stats[0]
+	duplicate.Duration = time.Minute
+	// the same start with other number of events is a different run
+	other := stats[0]
+	other.NumbOfEvents = 1000
+
+	writeStatistics(t, first, []ExecutionStatistic{stats[0], stats[1], stats[1]})
+	writeStatistics(t, second, []ExecutionStatistic{duplicate, other, stats[1]})
+
+	if err := Merge(out, first, second); err != nil {
+		t.Fatalf("unable to merge statistics : %v", err)
+	}
+
+	merged, err := GetAllStatistics(out)
+	if err != nil {
+		t.Fatalf("unable to read merged statistics : %v", err)
+	}
+	assertStarts(t, []time.Time{stats[0].ExecutionStart, stats[0].ExecutionStart, stats[1].ExecutionStart}, merged)
+	if merged[0].Duration != stats[0].Duration || merged[0].NumbOfEvents != stats[0].NumbOfEvents {
+		t.Errorf("expected the first record %+v to be kept, got %+v", stats[0], merged[0])
+	}
+	if merged[1].NumbOfEvents != other.NumbOfEvents {
+		t.Errorf("expected run of %d events, got %d", other.NumbOfEvents, merged[1].NumbOfEvents)
+	}
+}
+
+func TestMergeSkipsMalformedLines(t *testing.T) {
+	dir := t.TempDir()
+	stats := testStatistics()
+	in, out := filepath.Join(dir, "stats.json"), filepath.Join(dir, "merged.json")
+	writeStatistics(t, in, stats[:2], "{not a statistic", "")
+
+	if err := Merge(out, in); err != nil {
+		t.Fatalf("unable to merge statistics : %v", err)
+	}
+
+	merged, err := GetAllStatistics(out)
+	if err != nil {
+		t.Fatalf("unable to read merged statistics : %v", err)
+	}
+	assertStarts(t, []time.Time{stats[0].ExecutionStart, stats[1].ExecutionStart}, merged)
+}
+
+func TestMergeIntoInput(t *testing.T) {
+	dir := t.TempDir()
+	stats := testStatistics()
+	first, second := filepath.Join(dir, "first.json"), filepath.Join(dir, "second.json")
+	writeStatistics(t, first, stats[2:])
+	writeStatistics(t, second, stats[:2])
+
+	if err := Merge(first, first, second); err != nil {
+		t.Fatalf("unable to merge statistics : %v", err)
+	}
+
+	merged, err := GetAllStatistics(first)
+	if err != nil {
+		t.Fatalf("unable to read merged statistics : %v", err)
+	}
+	if len(merged) != len(stats) {
+		t.Errorf("expected %d statistics, got %d", len(stats), len(merged))
+	}
+}
+
+func TestMergeMissingInput(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "merged.json")
+
+	if err := Merge(out, filepath.Join(dir, "missing.json")); !errors.Is(err, ErrStatisticsNotFound) {
+		t.Fatalf("expected statistics not found error, got %v", err)
+	}
+	if _, err := os.Stat(out); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected output not to be written, got %v", err)
+	}
+}
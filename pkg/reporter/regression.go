@@ -0,0 +1,30 @@
+package reporter
+
+import (
+	"fmt"
+)
+
+// CheckRegression reports whether the latest run with provided number of events is slower than median of
+// the previous runs with the same number of events by more than maxRegressPct percent.
+// The only run has no baseline, so it's not treated as regression.
+// ErrStatisticsNotFound is returned if there is no run with provided number of events.
+func CheckRegression(filename string, eventCount int, maxRegressPct float64) (bool, error) {
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		return false, err
+	}
+
+	runs := FilterByNumbOfEvents(stats, eventCount)
+	if len(runs) == 0 {
+		return false, fmt.Errorf("%s : no runs of %d events : %w", filename, eventCount, ErrStatisticsNotFound)
+	}
+
+	latest := runs[len(runs)-1]
+	baseline := Median(runs[:len(runs)-1])
+	if baseline == 0 {
+		return false, nil
+	}
+
+	regress := float64(latest.Duration-baseline) / float64(baseline) * 100
+	return regress > maxRegressPct, nil
+}
@@ -0,0 +1,51 @@
+package reporter
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckRegression(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	stats := testStatistics()
+	stats = append(stats, ExecutionStatistic{ExecutionStart: stats[4].ExecutionStart.Add(time.Hour), NumbOfEvents: 500, Duration: time.Second})
+	writeStatistics(t, filename, stats)
+
+	tests := []struct {
+		name          string
+		eventCount    int
+		maxRegressPct float64
+		expected      bool
+	}{
+		// the latest 5s run is ~43% slower than 3.5s median of 4s and 3s runs
+		{name: "regression", eventCount: 1000000, maxRegressPct: 10, expected: true},
+		{name: "regression within threshold", eventCount: 1000000, maxRegressPct: 50},
+		{name: "regression at threshold", eventCount: 1000000, maxRegressPct: float64(1500*time.Millisecond) / float64(3500*time.Millisecond) * 100},
+		// the latest 10ms run is faster than the previous 20ms one
+		{name: "improvement", eventCount: 1000, maxRegressPct: 0},
+		{name: "the only run", eventCount: 500, maxRegressPct: 0},
+	}
+	for _, test := range tests {
+		regressed, err := CheckRegression(filename, test.eventCount, test.maxRegressPct)
+		if err != nil {
+			t.Errorf("%s : unable to check regression : %v", test.name, err)
+		} else if regressed != test.expected {
+			t.Errorf("%s : expected regression %t, got %t", test.name, test.expected, regressed)
+		}
+	}
+}
+
+func TestCheckRegressionWithoutRuns(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "stats.json")
+	writeStatistics(t, filename, testStatistics())
+
+	if _, err := CheckRegression(filename, 42, 10); !errors.Is(err, ErrStatisticsNotFound) {
+		t.Errorf("expected statistics not found error for unknown number of events, got %v", err)
+	}
+	if _, err := CheckRegression(filepath.Join(dir, "missing.json"), 1000, 10); !errors.Is(err, ErrStatisticsNotFound) {
+		t.Errorf("expected statistics not found error for missing file, got %v", err)
+	}
+}
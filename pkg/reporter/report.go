@@ -0,0 +1,65 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ReportOptions configures output of Report.
+type ReportOptions struct {
+	// Histogram enables histogram of durations for every number of events.
+	Histogram bool
+	// Buckets is number of histogram buckets.
+	Buckets int
+}
+
+// Report will print summary of all the runs stored in provided file grouped by number of events.
+// ErrStatisticsNotFound is returned if the file does not exist.
+func Report(w io.Writer, filename string, opts ReportOptions) error {
+	stats, err := readStatistics(filename)
+	if err != nil {
+		return err
+	}
+
+	if len(stats) == 0 {
+		fmt.Fprintln(w, "no statistics found")
+		return nil
+	}
+
+	var counts []int
+	seen := map[int]bool{}
+	for _, s := range stats {
+		if !seen[s.NumbOfEvents] {
+			seen[s.NumbOfEvents] = true
+			counts = append(counts, s.NumbOfEvents)
+		}
+	}
+	sort.Ints(counts)
+
+	for _, count := range counts {
+		filtered := FilterByNumbOfEvents(stats, count)
+		summary := Summarize(filtered)
+
+		fmt.Fprintln(w, "================")
+		fmt.Fprintf(w, "Number Of Events : %s\n", FormatNumber(count))
+		fmt.Fprintf(w, "Runs   : %d\n", summary.Runs)
+		fmt.Fprintf(w, "Mean   : %v\n", summary.Mean)
+		fmt.Fprintf(w, "Median : %v\n", summary.Median)
+		fmt.Fprintf(w, "Min    : %v\n", summary.Min)
+		fmt.Fprintf(w, "Max    : %v\n", summary.Max)
+		// older records don't have parallelism settings
+		if last := filtered[len(filtered)-1]; last.GoMaxProcs > 0 {
+			fmt.Fprintf(w, "Procs  : GOMAXPROCS %d of %d CPUs in the latest run\n", last.GoMaxProcs, last.NumCPU)
+		}
+		if slope, err := Trend(stats, count); err == nil {
+			fmt.Fprintf(w, "Trend  : %s\n", formatTrend(slope))
+		}
+
+		if opts.Histogram {
+			fmt.Fprintln(w)
+			fmt.Fprint(w, Histogram(filtered, opts.Buckets))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,371 @@
+package reporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ANSI color codes used to highlight improvements and regressions.
+const (
+	colorReset = "\033[0m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+)
+
+// Colors enables highlighting of improvements and regressions with ANSI colors.
+// It's enabled if standard output is a terminal and NO_COLOR environment variable is not set.
+var Colors = ColorsEnabled(os.Stdout)
+
+// ColorsEnabled reports whether output to provided file should be colored,
+// colors are disabled for files and pipes and by NO_COLOR environment variable.
+func ColorsEnabled(f *os.File) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// colorize wraps s in provided color if colors are enabled.
+func colorize(s, color string, enabled bool) string {
+	if !enabled {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// ExecutionStatistic is a single measurement of application execution.
+type ExecutionStatistic struct {
+	// ExecutionStart is the time when execution was started.
+	ExecutionStart time.Time `json:"execution_start"`
+	// NumbOfEvents is number of events processed during execution.
+	NumbOfEvents int `json:"numb_of_events"`
+	// Duration is total time of execution.
+	Duration time.Duration `json:"duration"`
+	// GitCommit is commit of the code which was executed, empty if it's unknown.
+	GitCommit string `json:"git_commit,omitempty"`
+	// Hostname is name of the host execution happened on, empty if it's unknown.
+	Hostname string `json:"hostname,omitempty"`
+	// GoMaxProcs is GOMAXPROCS of execution, zero for records saved before it was recorded.
+	GoMaxProcs int `json:"go_max_procs,omitempty"`
+	// NumCPU is number of logical CPUs of the host, zero for records saved before it was recorded.
+	NumCPU int `json:"num_cpu,omitempty"`
+}
+
+// Throughput returns number of processed events per second.
+func (s ExecutionStatistic) Throughput() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.NumbOfEvents) / s.Duration.Seconds()
+}
+
+// Save will append provided statistic to the file as a single json line and sync it to disk.
+// File will be created if it does not exist. GoMaxProcs and NumCPU are set to the current ones if not set.
+// Half written line of crashed run is terminated first, so it never corrupts the new one,
+// and failed write is truncated back, so the file always stays parseable.
+func Save(filename string, stat ExecutionStatistic) error {
+	stat = withRuntime(stat)
+	content, err := json.Marshal(stat)
+	if err != nil {
+		return fmt.Errorf("unable to marshall statistic : %w", err)
+	}
+	content = append(content, '\n')
+
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open statistics file : %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to stat statistics file : %w", err)
+	}
+	size := info.Size()
+
+	if size > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, size-1); err != nil {
+			return fmt.Errorf("unable to read statistics file : %w", err)
+		}
+		if last[0] != '\n' {
+			content = append([]byte{'\n'}, content...)
+		}
+	}
+
+	if _, err := file.Write(content); err != nil {
+		// drop partially written line
+		file.Truncate(size)
+		return fmt.Errorf("unable to write statistic : %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("unable to sync statistics file : %w", err)
+	}
+	return nil
+}
+
+// withRuntime returns statistic with GoMaxProcs and NumCPU set to the current ones unless they are already set.
+func withRuntime(stat ExecutionStatistic) ExecutionStatistic {
+	if stat.GoMaxProcs == 0 {
+		stat.GoMaxProcs = runtime.GOMAXPROCS(0)
+	}
+	if stat.NumCPU == 0 {
+		stat.NumCPU = runtime.NumCPU()
+	}
+	return stat
+}
+
+// ErrStatisticsNotFound is returned when statistics file does not exist, other I/O errors wrap underlying os errors.
+var ErrStatisticsNotFound = errors.New("statistics file not found")
+
+// GetAllStatistics will read all the statistics stored in provided file.
+// Lines which can't be parsed are skipped, missing file means there are no statistics yet.
+func GetAllStatistics(filename string) ([]ExecutionStatistic, error) {
+	stats, err := readStatistics(filename)
+	if errors.Is(err, ErrStatisticsNotFound) {
+		return nil, nil
+	}
+	return stats, err
+}
+
+// Latest returns the most recently stored statistic of runs with provided number of events.
+// Found is false if there is no such run, missing file means there are no statistics yet.
+func Latest(filename string, eventCount int) (*ExecutionStatistic, bool, error) {
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i := len(stats) - 1; i >= 0; i-- {
+		if stats[i].NumbOfEvents == eventCount {
+			return &stats[i], true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// readStatistics will read all the statistics stored in provided file, missing file is reported with ErrStatisticsNotFound.
+func readStatistics(filename string) ([]ExecutionStatistic, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s : %w", filename, ErrStatisticsNotFound)
+		}
+		return nil, fmt.Errorf("unable to open statistics file : %w", err)
+	}
+	defer file.Close()
+
+	var stats []ExecutionStatistic
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var stat ExecutionStatistic
+		if err := json.Unmarshal(scanner.Bytes(), &stat); err != nil {
+			continue
+		}
+		stats = append(stats, stat)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read statistics file : %w", err)
+	}
+	return stats, nil
+}
+
+// Output is destination of the reports printed by SaveAndReport, Compare and Watch, it's standard output by default.
+var Output io.Writer = os.Stdout
+
+// SaveAndReport will save provided statistic and print comparison with the first run and the median of prior runs
+// with the same number of events.
+func SaveAndReport(filename string, stat ExecutionStatistic) error {
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		return err
+	}
+
+	// report shows what is saved rather than what the reporting process runs with
+	stat = withRuntime(stat)
+	if err := Save(filename, stat); err != nil {
+		return err
+	}
+
+	sameSize := FilterByNumbOfEvents(stats, stat.NumbOfEvents)
+
+	fmt.Fprintf(Output, "Number Of Events : %s\n", FormatNumber(stat.NumbOfEvents))
+	fmt.Fprintf(Output, "Duration : %v\n", stat.Duration)
+	fmt.Fprintf(Output, "Throughput : %s events/sec\n", FormatNumber(int(stat.Throughput())))
+	if stat.GitCommit != "" {
+		fmt.Fprintf(Output, "Git Commit : %s\n", stat.GitCommit)
+	}
+	if stat.Hostname != "" {
+		fmt.Fprintf(Output, "Hostname : %s\n", stat.Hostname)
+	}
+	fmt.Fprintf(Output, "GOMAXPROCS / CPUs : %d / %d\n", stat.GoMaxProcs, stat.NumCPU)
+
+	if len(sameSize) == 0 {
+		fmt.Fprintln(Output, "This is the first run with such number of events")
+		return nil
+	}
+
+	first := sameSize[0]
+	// median of prior runs is far less noisy than a single previous run
+	median := ExecutionStatistic{NumbOfEvents: stat.NumbOfEvents, Duration: Median(sameSize)}
+
+	fmt.Fprintf(Output, "Comparing With First Run (%s) : %s\n",
+		first.ExecutionStart.Format(time.RFC3339), calculateImprovement(first.Duration, stat.Duration, Colors))
+	fmt.Fprintf(Output, "Comparing With Median Of %d Prior Runs (%v) : %s\n",
+		len(sameSize), median.Duration, calculateImprovement(median.Duration, stat.Duration, Colors))
+	fmt.Fprintf(Output, "Throughput Delta With First Run : %s events/sec\n", formatDelta(stat.Throughput()-first.Throughput()))
+	fmt.Fprintf(Output, "Throughput Delta With Median : %s events/sec\n", formatDelta(stat.Throughput()-median.Throughput()))
+	return nil
+}
+
+// Summary is aggregated view on a set of execution statistics.
+type Summary struct {
+	// Runs is number of aggregated runs.
+	Runs int
+	// Mean is average duration of runs.
+	Mean time.Duration
+	// Median is median duration of runs.
+	Median time.Duration
+	// Min is the fastest run duration.
+	Min time.Duration
+	// Max is the slowest run duration.
+	Max time.Duration
+}
+
+// Summarize will aggregate durations of provided statistics.
+// Empty summary is returned for empty statistics.
+func Summarize(stats []ExecutionStatistic) Summary {
+	if len(stats) == 0 {
+		return Summary{}
+	}
+
+	durations := make([]time.Duration, 0, len(stats))
+	var total time.Duration
+	for _, s := range stats {
+		durations = append(durations, s.Duration)
+		total += s.Duration
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	return Summary{
+		Runs:   len(stats),
+		Mean:   total / time.Duration(len(stats)),
+		Median: medianOf(durations),
+		Min:    durations[0],
+		Max:    durations[len(durations)-1],
+	}
+}
+
+// Median returns median duration of provided statistics, average of two middle durations is used
+// for even number of statistics. Zero is returned for empty statistics.
+func Median(stats []ExecutionStatistic) time.Duration {
+	if len(stats) == 0 {
+		return 0
+	}
+
+	durations := make([]time.Duration, 0, len(stats))
+	for _, s := range stats {
+		durations = append(durations, s.Duration)
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return medianOf(durations)
+}
+
+// medianOf returns median of sorted non-empty durations.
+func medianOf(durations []time.Duration) time.Duration {
+	median := durations[len(durations)/2]
+	if len(durations)%2 == 0 {
+		median = (durations[len(durations)/2-1] + durations[len(durations)/2]) / 2
+	}
+	return median
+}
+
+// calculateImprovement will format the difference between base and current durations in percents.
+// Improvements are printed green, regressions are printed red.
+func calculateImprovement(base, current time.Duration, color bool) string {
+	if base == 0 {
+		return "N/A"
+	}
+
+	diff := float64(base-current) / float64(base) * 100
+	if diff >= 0 {
+		return colorize(fmt.Sprintf("%.2f%% faster", diff), colorGreen, color)
+	}
+	return colorize(fmt.Sprintf("%.2f%% slower", -diff), colorRed, color)
+}
+
+// formatDelta will format throughput difference with explicit sign.
+func formatDelta(delta float64) string {
+	if delta >= 0 {
+		return "+" + FormatNumber(int(delta))
+	}
+	return FormatNumber(int(delta))
+}
+
+// Separator is thousands separator of formatted numbers, it's picked by LC_NUMERIC locale, comma by default.
+var Separator = LocaleSeparator(numericLocale())
+
+// localeSeparators are thousands separators of locale languages not using comma.
+var localeSeparators = map[string]string{
+	"de": ".", "es": ".", "it": ".", "nl": ".", "pt": ".", "da": ".", "id": ".", "tr": ".",
+	"fr": " ", "ru": " ", "uk": " ", "pl": " ", "cs": " ", "sk": " ", "sv": " ", "fi": " ", "nb": " ",
+}
+
+// numericLocale returns locale of number formatting, LC_ALL overrides LC_NUMERIC.
+func numericLocale() string {
+	if locale := os.Getenv("LC_ALL"); locale != "" {
+		return locale
+	}
+	return os.Getenv("LC_NUMERIC")
+}
+
+// LocaleSeparator returns thousands separator of locale like de_DE.UTF-8, comma is used for unknown locales.
+func LocaleSeparator(locale string) string {
+	language := locale
+	if i := strings.IndexAny(locale, "_-.@"); i >= 0 {
+		language = locale[:i]
+	}
+	if sep, ok := localeSeparators[strings.ToLower(language)]; ok {
+		return sep
+	}
+	return ","
+}
+
+// FormatNumber will format number with Separator as thousands separator.
+func FormatNumber(n int) string {
+	return FormatNumberLocale(n, Separator)
+}
+
+// FormatNumberLocale will format number with provided thousands separator.
+func FormatNumberLocale(n int, sep string) string {
+	if n < 0 {
+		// format absolute value, so the sign is not counted as a digit position.
+		// negation of the minimal int overflows, so it's formatted from its unsigned representation
+		return "-" + groupDigits(strconv.FormatUint(uint64(-(n+1))+1, 10), sep)
+	}
+	return groupDigits(strconv.Itoa(n), sep)
+}
+
+// groupDigits will insert separator between every group of 3 digits.
+func groupDigits(s, sep string) string {
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,381 @@
+package reporter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveRoundTripsProcs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	start := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	saved := []ExecutionStatistic{
+		{ExecutionStart: start, NumbOfEvents: 1000, Duration: time.Second, GoMaxProcs: 3, NumCPU: 8},
+		// not set fields are recorded from the current process
+		{ExecutionStart: start, NumbOfEvents: 1000, Duration: time.Second},
+	}
+	for _, stat := range saved {
+		if err := Save(filename, stat); err != nil {
+			t.Fatalf("unable to save statistic : %v", err)
+		}
+	}
+
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 statistics, got %d", len(stats))
+	}
+	if stats[0] != saved[0] {
+		t.Errorf("expected %+v, got %+v", saved[0], stats[0])
+	}
+	if stats[1].GoMaxProcs != runtime.GOMAXPROCS(0) || stats[1].NumCPU != runtime.NumCPU() {
+		t.Errorf("expected current GOMAXPROCS %d and CPUs %d, got %d and %d",
+			runtime.GOMAXPROCS(0), runtime.NumCPU(), stats[1].GoMaxProcs, stats[1].NumCPU)
+	}
+}
+
+func TestGetAllStatisticsReadsRecordsWithoutProcs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	old := `{"execution_start":"2022-03-14T15:09:26Z","numb_of_events":1000,"duration":1000000000}` + "\n"
+	if err := os.WriteFile(filename, []byte(old), 0644); err != nil {
+		t.Fatalf("unable to write statistics : %v", err)
+	}
+
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+
+	expected := ExecutionStatistic{
+		ExecutionStart: time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC),
+		NumbOfEvents:   1000,
+		Duration:       time.Second,
+	}
+	if len(stats) != 1 || !stats[0].ExecutionStart.Equal(expected.ExecutionStart) ||
+		stats[0].NumbOfEvents != expected.NumbOfEvents || stats[0].Duration != expected.Duration ||
+		stats[0].GoMaxProcs != 0 || stats[0].NumCPU != 0 {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestSaveAndReportPrintsRecordedProcs(t *testing.T) {
+	defer func(w io.Writer) { Output = w }(Output)
+	var buf bytes.Buffer
+	Output = &buf
+
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	stat := ExecutionStatistic{ExecutionStart: time.Now(), NumbOfEvents: 1000, Duration: time.Second, GoMaxProcs: 123, NumCPU: 456}
+	if err := SaveAndReport(filename, stat); err != nil {
+		t.Fatalf("unable to save statistic : %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "GOMAXPROCS / CPUs : 123 / 456\n") {
+		t.Errorf("expected recorded GOMAXPROCS and CPUs in report, got:\n%s", buf.String())
+	}
+}
+
+func TestCalculateImprovement(t *testing.T) {
+	tests := []struct {
+		name          string
+		base, current time.Duration
+		color         bool
+		expected      string
+	}{
+		{name: "faster colored", base: 4 * time.Second, current: 3 * time.Second, color: true, expected: "\x1b[32m25.00% faster\x1b[0m"},
+		{name: "slower colored", base: 4 * time.Second, current: 5 * time.Second, color: true, expected: "\x1b[31m25.00% slower\x1b[0m"},
+		{name: "faster plain", base: 4 * time.Second, current: 3 * time.Second, expected: "25.00% faster"},
+		{name: "slower plain", base: 4 * time.Second, current: 5 * time.Second, expected: "25.00% slower"},
+		{name: "same plain", base: 4 * time.Second, current: 4 * time.Second, expected: "0.00% faster"},
+		{name: "no base colored", current: 4 * time.Second, color: true, expected: "N/A"},
+	}
+
+	for _, test := range tests {
+		if actual := calculateImprovement(test.base, test.current, test.color); actual != test.expected {
+			t.Errorf("%s : expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestSaveAndReportColors(t *testing.T) {
+	defer func(w io.Writer) { Output = w }(Output)
+	start := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	for _, colors := range []bool{true, false} {
+		withFormatting(t, colors, ",")
+		var buf bytes.Buffer
+		Output = &buf
+
+		filename := filepath.Join(t.TempDir(), "stats.json")
+		for i, duration := range []time.Duration{4 * time.Second, 3 * time.Second} {
+			stat := ExecutionStatistic{ExecutionStart: start.Add(time.Duration(i) * time.Hour), NumbOfEvents: 1000, Duration: duration}
+			if err := SaveAndReport(filename, stat); err != nil {
+				t.Fatalf("unable to save statistic : %v", err)
+			}
+		}
+
+		report := buf.String()
+		if colored := strings.Contains(report, "\x1b["); colored != colors {
+			t.Errorf("colors %t : expected colored output %t, got:\n%q", colors, colors, report)
+		}
+		if !strings.Contains(stripColors(report), "25.00% faster") {
+			t.Errorf("colors %t : expected improvement in report, got:\n%q", colors, report)
+		}
+	}
+}
+
+func TestColorsEnabled(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "report.log"))
+	if err != nil {
+		t.Fatalf("unable to create file : %v", err)
+	}
+	defer file.Close()
+
+	if ColorsEnabled(file) {
+		t.Error("expected colors to be disabled for regular file")
+	}
+
+	t.Setenv("NO_COLOR", "")
+	if ColorsEnabled(os.Stdout) {
+		t.Error("expected colors to be disabled by NO_COLOR")
+	}
+}
+
+func TestLatest(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	stats := testStatistics()
+	writeStatistics(t, filename, stats, "{malformed")
+
+	tests := []struct {
+		eventCount int
+		expected   *ExecutionStatistic
+	}{
+		{eventCount: 1000000, expected: &stats[3]},
+		{eventCount: 1000, expected: &stats[4]},
+		{eventCount: 42},
+	}
+	for _, test := range tests {
+		latest, found, err := Latest(filename, test.eventCount)
+		if err != nil {
+			t.Fatalf("unable to read latest statistic : %v", err)
+		}
+		if found != (test.expected != nil) {
+			t.Fatalf("%d events : expected found %t, got %t", test.eventCount, test.expected != nil, found)
+		}
+		if test.expected != nil && (!latest.ExecutionStart.Equal(test.expected.ExecutionStart) || latest.Duration != test.expected.Duration) {
+			t.Errorf("%d events : expected %+v, got %+v", test.eventCount, *test.expected, *latest)
+		}
+		if test.expected == nil && latest != nil {
+			t.Errorf("%d events : expected no statistic, got %+v", test.eventCount, *latest)
+		}
+	}
+}
+
+func TestLatestIsLastStored(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	stats := testStatistics()
+	// merged statistics of another machine could be stored after the newer ones
+	writeStatistics(t, filename, []ExecutionStatistic{stats[2], stats[1], stats[0]})
+
+	latest, found, err := Latest(filename, 1000000)
+	if err != nil {
+		t.Fatalf("unable to read latest statistic : %v", err)
+	}
+	if !found || !latest.ExecutionStart.Equal(stats[0].ExecutionStart) {
+		t.Errorf("expected the last stored statistic %+v, got %+v", stats[0], latest)
+	}
+}
+
+func TestLatestOfMissingFile(t *testing.T) {
+	latest, found, err := Latest(filepath.Join(t.TempDir(), "missing.json"), 1000)
+	if err != nil || found || latest != nil {
+		t.Errorf("expected nil, false, nil, got %v, %t, %v", latest, found, err)
+	}
+}
+
+func TestSaveRoundTripsMetadata(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	// old record without metadata is followed by the extended one
+	old := `{"execution_start":"2022-03-14T15:09:26Z","numb_of_events":1000,"duration":1000000000}` + "\n"
+	if err := os.WriteFile(filename, []byte(old), 0644); err != nil {
+		t.Fatalf("unable to write statistics : %v", err)
+	}
+
+	saved := testStatistics()[0]
+	if err := Save(filename, saved); err != nil {
+		t.Fatalf("unable to save statistic : %v", err)
+	}
+
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 statistics, got %d", len(stats))
+	}
+	if stats[0].GitCommit != "" || stats[0].Hostname != "" {
+		t.Errorf("expected old record without metadata, got %+v", stats[0])
+	}
+	if stats[1] != saved {
+		t.Errorf("expected %+v, got %+v", saved, stats[1])
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+	if !strings.Contains(string(content), `"git_commit":"abc1234","hostname":"host-1"`) {
+		t.Errorf("expected git_commit and hostname keys, got %s", content)
+	}
+}
+
+func TestSaveAndReportPrintsMetadata(t *testing.T) {
+	defer func(w io.Writer) { Output = w }(Output)
+	dir := t.TempDir()
+
+	for _, test := range []struct {
+		stat     ExecutionStatistic
+		expected bool
+	}{
+		{stat: testStatistics()[0], expected: true},
+		{stat: testStatistics()[1]},
+	} {
+		var buf bytes.Buffer
+		Output = &buf
+		if err := SaveAndReport(filepath.Join(dir, "stats.json"), test.stat); err != nil {
+			t.Fatalf("unable to save statistic : %v", err)
+		}
+
+		report := buf.String()
+		for _, line := range []string{"Git Commit : abc1234\n", "Hostname : host-1\n"} {
+			if strings.Contains(report, line) != test.expected {
+				t.Errorf("expected %q in report %t, got:\n%s", line, test.expected, report)
+			}
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		expected  time.Duration
+	}{
+		{name: "no runs"},
+		{name: "single run", durations: []time.Duration{3 * time.Second}, expected: 3 * time.Second},
+		{name: "odd runs", durations: []time.Duration{5 * time.Second, time.Second, 3 * time.Second}, expected: 3 * time.Second},
+		{name: "even runs", durations: []time.Duration{4 * time.Second, time.Second, 9 * time.Second, 2 * time.Second}, expected: 3 * time.Second},
+		{name: "even runs rounding", durations: []time.Duration{2, 1}, expected: 1},
+	}
+
+	for _, test := range tests {
+		stats := make([]ExecutionStatistic, 0, len(test.durations))
+		for _, d := range test.durations {
+			stats = append(stats, ExecutionStatistic{NumbOfEvents: 1000, Duration: d})
+		}
+		if actual := Median(stats); actual != test.expected {
+			t.Errorf("%s : expected %v, got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestSaveAndReportComparesWithMedian(t *testing.T) {
+	defer func(w io.Writer) { Output = w }(Output)
+	withFormatting(t, false, ",")
+	var buf bytes.Buffer
+	Output = &buf
+
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	start := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+	prior := []ExecutionStatistic{
+		{ExecutionStart: start, NumbOfEvents: 1000, Duration: 4 * time.Second},
+		{ExecutionStart: start.Add(time.Hour), NumbOfEvents: 1000, Duration: 8 * time.Second},
+		// runs of other number of events are not compared
+		{ExecutionStart: start.Add(2 * time.Hour), NumbOfEvents: 2000, Duration: time.Minute},
+		{ExecutionStart: start.Add(3 * time.Hour), NumbOfEvents: 1000, Duration: 2 * time.Second},
+	}
+	for _, stat := range prior {
+		if err := Save(filename, stat); err != nil {
+			t.Fatalf("unable to save statistic : %v", err)
+		}
+	}
+
+	stat := ExecutionStatistic{ExecutionStart: start.Add(4 * time.Hour), NumbOfEvents: 1000, Duration: 3 * time.Second}
+	if err := SaveAndReport(filename, stat); err != nil {
+		t.Fatalf("unable to save statistic : %v", err)
+	}
+
+	report := buf.String()
+	for _, line := range []string{
+		"Comparing With First Run (2022-03-14T15:09:26Z) : 25.00% faster",
+		"Comparing With Median Of 3 Prior Runs (4s) : 25.00% faster",
+	} {
+		if !strings.Contains(report, line) {
+			t.Errorf("expected %q in report, got:\n%s", line, report)
+		}
+	}
+}
+
+func TestGetAllStatisticsDistinguishesNotFound(t *testing.T) {
+	dir := t.TempDir()
+
+	stats, err := GetAllStatistics(filepath.Join(dir, "missing.json"))
+	if err != nil || stats != nil {
+		t.Errorf("expected no statistics of missing file, got %v and %v", stats, err)
+	}
+
+	// directory can't be read as statistics file, which is not the same as missing file
+	_, err = GetAllStatistics(dir)
+	if err == nil || errors.Is(err, ErrStatisticsNotFound) {
+		t.Errorf("expected I/O error of directory, got %v", err)
+	}
+}
+
+func TestSaveAfterShortWrite(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	start := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	first := ExecutionStatistic{ExecutionStart: start, NumbOfEvents: 1000, Duration: time.Second}
+	if err := Save(filename, first); err != nil {
+		t.Fatalf("unable to save statistic : %v", err)
+	}
+
+	// process killed in the middle of write leaves half of the line
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics file : %v", err)
+	}
+	if err := os.WriteFile(filename, append(content, content[:len(content)/2]...), 0644); err != nil {
+		t.Fatalf("unable to write statistics file : %v", err)
+	}
+
+	second := ExecutionStatistic{ExecutionStart: start.Add(time.Hour), NumbOfEvents: 1000, Duration: 2 * time.Second}
+	if err := Save(filename, second); err != nil {
+		t.Fatalf("unable to save statistic : %v", err)
+	}
+
+	stats, err := GetAllStatistics(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+	// the half line is dropped without gluing the next record to it
+	if len(stats) != 2 || !stats[0].ExecutionStart.Equal(first.ExecutionStart) || !stats[1].ExecutionStart.Equal(second.ExecutionStart) {
+		t.Fatalf("expected the first and the second statistics, got %+v", stats)
+	}
+
+	content, err = os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("unable to read statistics file : %v", err)
+	}
+	if !bytes.HasSuffix(content, []byte("}\n")) {
+		t.Errorf("expected complete last line, got %q", content)
+	}
+}
@@ -0,0 +1,32 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// PrintTable will print statistics as a table with aligned columns, one run per row in stored order.
+// Improvement is relative to the first run with the same number of events.
+func PrintTable(w io.Writer, stats []ExecutionStatistic) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Run Start\tEvents\tDuration\tThroughput\tVs First Run")
+
+	first := map[int]time.Duration{}
+	for _, s := range stats {
+		base, ok := first[s.NumbOfEvents]
+		if !ok {
+			first[s.NumbOfEvents] = s.Duration
+		}
+
+		// improvement is the last column as colors don't take space, but are counted by tabwriter
+		improvement := "first run"
+		if ok {
+			improvement = calculateImprovement(base, s.Duration, Colors)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%s/sec\t%s\n", s.ExecutionStart.Format(time.RFC3339), FormatNumber(s.NumbOfEvents),
+			s.Duration, FormatNumber(int(s.Throughput())), improvement)
+	}
+	return tw.Flush()
+}
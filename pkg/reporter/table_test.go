@@ -0,0 +1,22 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintTable(t *testing.T) {
+	withFormatting(t, true, ",")
+
+	var buf bytes.Buffer
+	if err := PrintTable(&buf, testStatistics()); err != nil {
+		t.Fatalf("unable to print table : %v", err)
+	}
+
+	// colors are enabled, so the table must stay aligned with them
+	if !strings.Contains(buf.String(), colorGreen) {
+		t.Errorf("expected colored improvements, got:\n%s", buf.String())
+	}
+	assertGolden(t, "table.golden", []byte(stripColors(buf.String())))
+}
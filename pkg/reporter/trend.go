@@ -0,0 +1,43 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotEnoughRuns is returned when there are less than two runs to find trend of.
+var ErrNotEnoughRuns = errors.New("at least two runs are required")
+
+// Trend fits linear regression of duration against run index of runs with provided number of events,
+// runs are taken in order they are stored. Returns slope in nanoseconds per run, negative slope means
+// runs are getting faster.
+func Trend(stats []ExecutionStatistic, eventCount int) (float64, error) {
+	runs := FilterByNumbOfEvents(stats, eventCount)
+	if len(runs) < 2 {
+		return 0, fmt.Errorf("%d runs of %d events : %w", len(runs), eventCount, ErrNotEnoughRuns)
+	}
+
+	n := float64(len(runs))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, run := range runs {
+		x, y := float64(i), float64(run.Duration)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX), nil
+}
+
+// formatTrend returns human readable trend of provided slope.
+func formatTrend(slope float64) string {
+	perRun := time.Duration(slope)
+	switch {
+	case perRun < 0:
+		return fmt.Sprintf("%s (%v per run)", colorize("improving", colorGreen, Colors), perRun)
+	case perRun > 0:
+		return fmt.Sprintf("%s (+%v per run)", colorize("regressing", colorRed, Colors), perRun)
+	}
+	return "stable"
+}
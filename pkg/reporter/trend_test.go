@@ -0,0 +1,60 @@
+package reporter
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrend(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    []ExecutionStatistic
+		expected float64
+		err      error
+	}{
+		{name: "no runs", err: ErrNotEnoughRuns},
+		{name: "single run", stats: runs(time.Second), err: ErrNotEnoughRuns},
+		// only runs of requested number of events are fitted
+		{name: "single run of the count", stats: append(runs(time.Second), ExecutionStatistic{NumbOfEvents: 5, Duration: time.Second}), err: ErrNotEnoughRuns},
+		{name: "stable", stats: runs(time.Second, time.Second, time.Second), expected: 0},
+		{name: "regressing", stats: runs(time.Second, 2*time.Second, 3*time.Second), expected: float64(time.Second)},
+		{name: "improving", stats: runs(3*time.Second, 2*time.Second), expected: -float64(time.Second)},
+	}
+
+	for _, test := range tests {
+		slope, err := Trend(test.stats, 1000)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s : expected %v, got %v", test.name, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s : unable to find trend : %v", test.name, err)
+		} else if slope != test.expected {
+			t.Errorf("%s : expected slope %v, got %v", test.name, test.expected, slope)
+		}
+	}
+}
+
+func TestFormatTrend(t *testing.T) {
+	withFormatting(t, false, ",")
+
+	tests := []struct {
+		slope    float64
+		expected string
+	}{
+		{slope: 0, expected: "stable"},
+		{slope: float64(-250 * time.Millisecond), expected: "improving (-250ms per run)"},
+		{slope: float64(1500 * time.Millisecond), expected: "regressing (+1.5s per run)"},
+		// sub-nanosecond slope is stable
+		{slope: 0.4, expected: "stable"},
+	}
+
+	for _, test := range tests {
+		if actual := formatTrend(test.slope); actual != test.expected {
+			t.Errorf("%v : expected %q, got %q", test.slope, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package reporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch will follow provided statistics file and print to Output every appended statistic with its improvement
+// against the previous run with the same number of events. It blocks until watching fails.
+//
+// Parent directory is watched, so the file may be created later, truncated or rotated.
+func Watch(filename string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create file watcher : %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(filename)); err != nil {
+		return fmt.Errorf("unable to watch %s : %w", filepath.Dir(filename), err)
+	}
+
+	t := &tail{filename: filename, previous: map[int]ExecutionStatistic{}}
+
+	// already stored statistics are only used as a baseline
+	if err := t.read(func(ExecutionStatistic) {}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(Output, "watching %s\n", filename)
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(filename) {
+				continue
+			}
+
+			switch {
+			case event.Has(fsnotify.Create), event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+				// file is rotated, new one is read from the beginning
+				t.offset = 0
+				t.partial = nil
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
+				if err := t.read(printWatched(t.previous)); err != nil {
+					return err
+				}
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("file watcher failed : %w", err)
+		}
+	}
+}
+
+// tail reads statistics appended to the file since the last read.
+type tail struct {
+	filename string
+	offset   int64
+	// partial is the beginning of the line which is not completely written yet.
+	partial []byte
+	// previous is the last statistic of every number of events.
+	previous map[int]ExecutionStatistic
+}
+
+// read will call fn for every complete statistic line appended since the last read.
+func (t *tail) read(fn func(stat ExecutionStatistic)) error {
+	file, err := os.Open(t.filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("unable to open statistics file : %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to stat statistics file : %w", err)
+	}
+	if info.Size() < t.offset {
+		// file is truncated, start from the beginning
+		t.offset = 0
+		t.partial = nil
+	}
+
+	if _, err := file.Seek(t.offset, io.SeekStart); err != nil {
+		return fmt.Errorf("unable to seek statistics file : %w", err)
+	}
+
+	r := bufio.NewReader(file)
+	for {
+		line, err := r.ReadBytes('\n')
+		t.offset += int64(len(line))
+
+		if err == io.EOF {
+			t.partial = append(t.partial, line...)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read statistics file : %w", err)
+		}
+
+		line = append(t.partial, line...)
+		t.partial = nil
+
+		var stat ExecutionStatistic
+		if err := json.Unmarshal(bytes.TrimSpace(line), &stat); err != nil {
+			continue
+		}
+		fn(stat)
+		t.previous[stat.NumbOfEvents] = stat
+	}
+}
+
+// printWatched returns function printing statistic with its improvement against previous run.
+func printWatched(previous map[int]ExecutionStatistic) func(stat ExecutionStatistic) {
+	return func(stat ExecutionStatistic) {
+		improvement := "first run"
+		if prev, ok := previous[stat.NumbOfEvents]; ok {
+			improvement = calculateImprovement(prev.Duration, stat.Duration, Colors)
+		}
+		fmt.Fprintf(Output, "%s | %15s events | %15v | %s\n",
+			stat.ExecutionStart.Format(time.RFC3339), FormatNumber(stat.NumbOfEvents), stat.Duration, improvement)
+	}
+}
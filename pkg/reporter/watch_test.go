@@ -0,0 +1,64 @@
+package reporter
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTailPrintsAppendedStatistics(t *testing.T) {
+	withFormatting(t, false, ",")
+	defer func(w io.Writer) { Output = w }(Output)
+	var buf bytes.Buffer
+	Output = &buf
+
+	filename := filepath.Join(t.TempDir(), "stats.json")
+	start := time.Date(2022, 3, 14, 15, 9, 26, 0, time.UTC)
+	writeStatistics(t, filename, []ExecutionStatistic{
+		{ExecutionStart: start, NumbOfEvents: 1000, Duration: 20 * time.Millisecond},
+		{ExecutionStart: start.Add(time.Hour), NumbOfEvents: 1000, Duration: 10 * time.Millisecond},
+	})
+
+	tl := &tail{filename: filename, previous: map[int]ExecutionStatistic{}}
+	if err := tl.read(printWatched(tl.previous)); err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 printed statistics, got:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "2022-03-14T15:09:26Z") || !strings.HasSuffix(lines[0], "first run") {
+		t.Errorf("expected first run, got %q", lines[0])
+	}
+	if strings.HasSuffix(lines[1], "first run") {
+		t.Errorf("expected improvement against the first run, got %q", lines[1])
+	}
+
+	// partially written line is printed once it's complete
+	buf.Reset()
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("unable to open statistics : %v", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(`{"numb_of_events":1000,`); err != nil {
+		t.Fatalf("unable to append statistic : %v", err)
+	}
+	if err := tl.read(printWatched(tl.previous)); err != nil || buf.Len() != 0 {
+		t.Fatalf("expected nothing printed of partial line, got %q and error %v", buf.String(), err)
+	}
+	if _, err := file.WriteString("\"duration\":5000000}\n"); err != nil {
+		t.Fatalf("unable to append statistic : %v", err)
+	}
+	if err := tl.read(printWatched(tl.previous)); err != nil {
+		t.Fatalf("unable to read statistics : %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 1 || !strings.Contains(lines[0], "5ms") {
+		t.Errorf("expected completed statistic to be printed, got:\n%s", buf.String())
+	}
+}